@@ -0,0 +1,158 @@
+package goshopify
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// AccessScopeService is an interface for interfacing with the access scope
+// endpoint of the Shopify API.
+// See: https://help.shopify.com/api/reference/access/accessscope
+type AccessScopeService interface {
+	List(interface{}) ([]AccessScope, error)
+	Granted() (Scopes, error)
+	Missing(Scopes) (Scopes, error)
+
+	ListWithContext(context.Context, interface{}) ([]AccessScope, error)
+	GrantedWithContext(context.Context) (Scopes, error)
+	MissingWithContext(context.Context, Scopes) (Scopes, error)
+}
+
+// AccessScopeServiceOp handles communication with the access scope related
+// methods of the Shopify API.
+type AccessScopeServiceOp struct {
+	client *Client
+}
+
+// AccessScope represents a scope granted to the app by a shop
+type AccessScope struct {
+	Handle string `json:"handle"`
+}
+
+// AccessScopesResource represents the result from the
+// admin/oauth/access_scopes.json endpoint
+type AccessScopesResource struct {
+	AccessScopes []AccessScope `json:"access_scopes"`
+}
+
+// List the scopes the shop granted to the app.
+func (s *AccessScopeServiceOp) List(options interface{}) ([]AccessScope, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *AccessScopeServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]AccessScope, error) {
+	resource := new(AccessScopesResource)
+	err := s.client.GetWithContext(ctx, "admin/oauth/access_scopes.json", resource, options)
+	return resource.AccessScopes, err
+}
+
+// Granted returns the scopes the shop granted to the app.
+func (s *AccessScopeServiceOp) Granted() (Scopes, error) {
+	return s.GrantedWithContext(context.Background())
+}
+
+// GrantedWithContext is like Granted but uses ctx for the request.
+func (s *AccessScopeServiceOp) GrantedWithContext(ctx context.Context) (Scopes, error) {
+	accessScopes, err := s.ListWithContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	handles := make([]string, len(accessScopes))
+	for i, accessScope := range accessScopes {
+		handles[i] = accessScope.Handle
+	}
+	return ParseScopes(strings.Join(handles, ",")), nil
+}
+
+// Missing returns the required scopes that the shop did not grant, e.g. the
+// ones added to App.Scope by an update of the app. The shop has to authorize
+// the app again if any are missing.
+func (s *AccessScopeServiceOp) Missing(required Scopes) (Scopes, error) {
+	return s.MissingWithContext(context.Background(), required)
+}
+
+// MissingWithContext is like Missing but uses ctx for the request.
+func (s *AccessScopeServiceOp) MissingWithContext(ctx context.Context, required Scopes) (Scopes, error) {
+	granted, err := s.GrantedWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return granted.Missing(required), nil
+}
+
+// Scopes is a set of access scopes, such as "read_products".
+// See: https://help.shopify.com/api/getting-started/authentication/oauth/scopes
+type Scopes []string
+
+// ParseScopes parses a comma separated list of scopes, as in App.Scope or
+// the scope of an AccessToken. The scopes are sorted and deduplicated.
+func ParseScopes(scope string) Scopes {
+	seen := map[string]bool{}
+	scopes := Scopes{}
+	for _, s := range strings.Split(scope, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		scopes = append(scopes, s)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// Scopes returns the parsed scopes of the app.
+func (app App) Scopes() Scopes {
+	return ParseScopes(app.Scope)
+}
+
+// String returns the scopes separated by commas.
+func (s Scopes) String() string {
+	return strings.Join(s, ",")
+}
+
+// Has reports whether the scope is in the set or implied by one that is,
+// e.g. "read_products" is implied by "write_products".
+func (s Scopes) Has(scope string) bool {
+	for _, granted := range s {
+		if granted == scope || impliedScope(granted) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the required scopes that are neither in the set nor
+// implied by it.
+func (s Scopes) Missing(required Scopes) Scopes {
+	missing := Scopes{}
+	for _, scope := range required {
+		if !s.Has(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// Expand returns the scopes including the ones they imply.
+func (s Scopes) Expand() Scopes {
+	expanded := append(Scopes{}, s...)
+	for _, scope := range s {
+		if implied := impliedScope(scope); implied != "" {
+			expanded = append(expanded, implied)
+		}
+	}
+	return ParseScopes(expanded.String())
+}
+
+// impliedScope returns the read scope implied by a write scope, or "".
+func impliedScope(scope string) string {
+	for _, prefix := range []string{"write_", "unauthenticated_write_"} {
+		if strings.HasPrefix(scope, prefix) {
+			return strings.Replace(prefix, "write_", "read_", 1) + strings.TrimPrefix(scope, prefix)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package goshopify
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestAccessScopeList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/oauth/access_scopes.json",
+		httpmock.NewBytesResponder(200, loadFixture("access_scopes.json")))
+
+	accessScopes, err := client.AccessScope.List(nil)
+	if err != nil {
+		t.Errorf("AccessScope.List returned error: %v", err)
+	}
+
+	expected := []AccessScope{{"write_products"}, {"read_orders"}, {"unauthenticated_write_checkouts"}}
+	if !reflect.DeepEqual(accessScopes, expected) {
+		t.Errorf("AccessScope.List returned %+v, expected %+v", accessScopes, expected)
+	}
+}
+
+func TestAccessScopeGranted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/oauth/access_scopes.json",
+		httpmock.NewBytesResponder(200, loadFixture("access_scopes.json")))
+
+	granted, err := client.AccessScope.Granted()
+	if err != nil {
+		t.Errorf("AccessScope.Granted returned error: %v", err)
+	}
+
+	expected := Scopes{"read_orders", "unauthenticated_write_checkouts", "write_products"}
+	if !reflect.DeepEqual(granted, expected) {
+		t.Errorf("AccessScope.Granted returned %v, expected %v", granted, expected)
+	}
+}
+
+func TestAccessScopeMissing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/oauth/access_scopes.json",
+		httpmock.NewBytesResponder(200, loadFixture("access_scopes.json")))
+
+	missing, err := client.AccessScope.Missing(ParseScopes("read_products,write_orders,read_orders,unauthenticated_read_checkouts"))
+	if err != nil {
+		t.Errorf("AccessScope.Missing returned error: %v", err)
+	}
+
+	expected := Scopes{"write_orders"}
+	if !reflect.DeepEqual(missing, expected) {
+		t.Errorf("AccessScope.Missing returned %v, expected %v", missing, expected)
+	}
+}
+
+func TestAccessScopeMissingError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/oauth/access_scopes.json",
+		httpmock.NewStringResponder(401, `{"errors":"[API] Invalid API key or access token"}`))
+
+	if _, err := client.AccessScope.Missing(app.Scopes()); err == nil {
+		t.Error("AccessScope.Missing returned no error for an unauthorized request")
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	cases := []struct {
+		scope    string
+		expected Scopes
+	}{
+		{"", Scopes{}},
+		{"read_products", Scopes{"read_products"}},
+		{"write_orders, read_products,,write_orders ", Scopes{"read_products", "write_orders"}},
+	}
+	for _, c := range cases {
+		actual := ParseScopes(c.scope)
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("ParseScopes(%q) returned %v, expected %v", c.scope, actual, c.expected)
+		}
+	}
+
+	app := App{Scope: "write_products,read_orders"}
+	if scope := app.Scopes().String(); scope != "read_orders,write_products" {
+		t.Errorf("App.Scopes().String() returned %q, expected %q", scope, "read_orders,write_products")
+	}
+}
+
+func TestScopesHas(t *testing.T) {
+	scopes := ParseScopes("write_products,read_orders,unauthenticated_write_checkouts")
+	cases := []struct {
+		scope    string
+		expected bool
+	}{
+		{"write_products", true},
+		{"read_products", true},
+		{"read_orders", true},
+		{"write_orders", false},
+		{"unauthenticated_read_checkouts", true},
+		{"read_checkouts", false},
+		{"read_customers", false},
+	}
+	for _, c := range cases {
+		if actual := scopes.Has(c.scope); actual != c.expected {
+			t.Errorf("Scopes.Has(%q) returned %v, expected %v", c.scope, actual, c.expected)
+		}
+	}
+}
+
+func TestScopesExpand(t *testing.T) {
+	expanded := ParseScopes("write_products,read_products,unauthenticated_write_checkouts,read_orders").Expand()
+	expected := Scopes{"read_orders", "read_products", "unauthenticated_read_checkouts", "unauthenticated_write_checkouts", "write_products"}
+	if !reflect.DeepEqual(expanded, expected) {
+		t.Errorf("Scopes.Expand returned %v, expected %v", expanded, expected)
+	}
+}
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -17,6 +18,11 @@ type ApplicationChargeService interface {
 	Get(int, interface{}) (*ApplicationCharge, error)
 	List(interface{}) ([]ApplicationCharge, error)
 	Activate(ApplicationCharge) (*ApplicationCharge, error)
+
+	CreateWithContext(context.Context, ApplicationCharge) (*ApplicationCharge, error)
+	GetWithContext(context.Context, int, interface{}) (*ApplicationCharge, error)
+	ListWithContext(context.Context, interface{}) ([]ApplicationCharge, error)
+	ActivateWithContext(context.Context, ApplicationCharge) (*ApplicationCharge, error)
 }
 
 type ApplicationChargeServiceOp struct {
@@ -52,28 +58,48 @@ type ApplicationChargesResource struct {
 
 // Create creates new application charge.
 func (a ApplicationChargeServiceOp) Create(charge ApplicationCharge) (*ApplicationCharge, error) {
+	return a.CreateWithContext(context.Background(), charge)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (a ApplicationChargeServiceOp) CreateWithContext(ctx context.Context, charge ApplicationCharge) (*ApplicationCharge, error) {
 	path := fmt.Sprintf("%s.json", applicationChargesBasePath)
 	resource := &ApplicationChargeResource{}
-	return resource.Charge, a.client.Post(path, ApplicationChargeResource{Charge: &charge}, resource)
+	return resource.Charge, a.client.PostWithContext(ctx, path, ApplicationChargeResource{Charge: &charge}, resource)
 }
 
 // Get gets individual application charge.
 func (a ApplicationChargeServiceOp) Get(chargeID int, options interface{}) (*ApplicationCharge, error) {
+	return a.GetWithContext(context.Background(), chargeID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (a ApplicationChargeServiceOp) GetWithContext(ctx context.Context, chargeID int, options interface{}) (*ApplicationCharge, error) {
 	path := fmt.Sprintf("%s/%d.json", applicationChargesBasePath, chargeID)
 	resource := &ApplicationChargeResource{}
-	return resource.Charge, a.client.Get(path, resource, options)
+	return resource.Charge, a.client.GetWithContext(ctx, path, resource, options)
 }
 
 // List gets all application charges.
 func (a ApplicationChargeServiceOp) List(options interface{}) ([]ApplicationCharge, error) {
+	return a.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (a ApplicationChargeServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]ApplicationCharge, error) {
 	path := fmt.Sprintf("%s.json", applicationChargesBasePath)
 	resource := &ApplicationChargesResource{}
-	return resource.Charges, a.client.Get(path, resource, options)
+	return resource.Charges, a.client.GetWithContext(ctx, path, resource, options)
 }
 
 // Activate activates application charge.
 func (a ApplicationChargeServiceOp) Activate(charge ApplicationCharge) (*ApplicationCharge, error) {
+	return a.ActivateWithContext(context.Background(), charge)
+}
+
+// ActivateWithContext is like Activate but uses ctx for the request.
+func (a ApplicationChargeServiceOp) ActivateWithContext(ctx context.Context, charge ApplicationCharge) (*ApplicationCharge, error) {
 	path := fmt.Sprintf("%s/%d/activate.json", applicationChargesBasePath, charge.ID)
 	resource := &ApplicationChargeResource{}
-	return resource.Charge, a.client.Post(path, ApplicationChargeResource{Charge: &charge}, resource)
+	return resource.Charge, a.client.PostWithContext(ctx, path, ApplicationChargeResource{Charge: &charge}, resource)
 }
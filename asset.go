@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -15,6 +16,11 @@ type AssetService interface {
 	Get(int, string) (*Asset, error)
 	Update(int, Asset) (*Asset, error)
 	Delete(int, string) error
+
+	ListWithContext(context.Context, int, interface{}) ([]Asset, error)
+	GetWithContext(context.Context, int, string) (*Asset, error)
+	UpdateWithContext(context.Context, int, Asset) (*Asset, error)
+	DeleteWithContext(context.Context, int, string) error
 }
 
 // AssetServiceOp handles communication with the asset related methods of
@@ -55,35 +61,55 @@ type assetGetOptions struct {
 
 // List the metadata for all assets in the given theme
 func (s *AssetServiceOp) List(themeID int, options interface{}) ([]Asset, error) {
+	return s.ListWithContext(context.Background(), themeID, options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *AssetServiceOp) ListWithContext(ctx context.Context, themeID int, options interface{}) ([]Asset, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
 	resource := new(AssetsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Assets, err
 }
 
 // Get an asset by key from the given theme
 func (s *AssetServiceOp) Get(themeID int, key string) (*Asset, error) {
+	return s.GetWithContext(context.Background(), themeID, key)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *AssetServiceOp) GetWithContext(ctx context.Context, themeID int, key string) (*Asset, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
 	options := assetGetOptions{
 		Key:     key,
 		ThemeID: themeID,
 	}
 	resource := new(AssetResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Asset, err
 }
 
 // Update an asset
 func (s *AssetServiceOp) Update(themeID int, asset Asset) (*Asset, error) {
+	return s.UpdateWithContext(context.Background(), themeID, asset)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *AssetServiceOp) UpdateWithContext(ctx context.Context, themeID int, asset Asset) (*Asset, error) {
 	path := fmt.Sprintf("%s/%d/assets.json", assetsBasePath, themeID)
 	wrappedData := AssetResource{Asset: &asset}
 	resource := new(AssetResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Asset, err
 }
 
 // Delete an asset
 func (s *AssetServiceOp) Delete(themeID int, key string) error {
+	return s.DeleteWithContext(context.Background(), themeID, key)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *AssetServiceOp) DeleteWithContext(ctx context.Context, themeID int, key string) error {
 	path := fmt.Sprintf("%s/%d/assets.json?asset[key]=%s", assetsBasePath, themeID, key)
-	return s.client.Delete(path)
+	return s.client.DeleteWithContext(ctx, path)
 }
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -12,11 +13,23 @@ const blogsBasePath = "admin/blogs"
 // See: https://help.shopify.com/api/reference/online_store/blog
 type BlogService interface {
 	List(interface{}) ([]Blog, error)
+	ListPage(interface{}) ([]Blog, *Pagination, error)
+	ListAll(interface{}) ([]Blog, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Blog, error)
 	Create(Blog) (*Blog, error)
 	Update(Blog) (*Blog, error)
 	Delete(int) error
+
+	ListWithContext(context.Context, interface{}) ([]Blog, error)
+	ListPageWithContext(context.Context, interface{}) ([]Blog, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Blog, error)
+	Iterate(context.Context, interface{}) <-chan BlogResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Blog, error)
+	CreateWithContext(context.Context, Blog) (*Blog, error)
+	UpdateWithContext(context.Context, Blog) (*Blog, error)
+	DeleteWithContext(context.Context, int) error
 }
 
 // BlogServiceOp handles communication with the blog related methods of
@@ -45,6 +58,12 @@ type BlogsResource struct {
 	Blogs []Blog `json:"blogs"`
 }
 
+// BlogResult is a blog or an error from Iterate.
+type BlogResult struct {
+	Blog Blog
+	Err  error
+}
+
 // Represents the result from the blogs/X.json endpoint
 type BlogResource struct {
 	Blog *Blog `json:"blog"`
@@ -52,45 +71,137 @@ type BlogResource struct {
 
 // List all blogs
 func (s *BlogServiceOp) List(options interface{}) ([]Blog, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *BlogServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Blog, error) {
 	path := fmt.Sprintf("%s.json", blogsBasePath)
 	resource := new(BlogsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Blogs, err
 }
 
+// ListPage lists a page of blogs along with its pagination
+func (s *BlogServiceOp) ListPage(options interface{}) ([]Blog, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *BlogServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Blog, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", blogsBasePath)
+	resource := new(BlogsResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Blogs, pagination, err
+}
+
+// ListAll lists all blogs, following the pagination from the given options
+func (s *BlogServiceOp) ListAll(options interface{}) ([]Blog, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *BlogServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Blog, error) {
+	var collector []Blog
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		blogs, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, blogs...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all blogs over the returned channel, fetching the pages
+// lazily. The channel is closed after the last blog or after a result
+// with an error. Cancel ctx to stop early.
+func (s *BlogServiceOp) Iterate(ctx context.Context, options interface{}) <-chan BlogResult {
+	results := make(chan BlogResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			blogs, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, blog := range blogs {
+				select {
+				case results <- BlogResult{Blog: blog}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- BlogResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count blogs
 func (s *BlogServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *BlogServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", blogsBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get single blog
 func (s *BlogServiceOp) Get(blogId int, options interface{}) (*Blog, error) {
+	return s.GetWithContext(context.Background(), blogId, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *BlogServiceOp) GetWithContext(ctx context.Context, blogId int, options interface{}) (*Blog, error) {
 	path := fmt.Sprintf("%s/%d.json", blogsBasePath, blogId)
 	resource := new(BlogResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Blog, err
 }
 
 // Create a new blog
 func (s *BlogServiceOp) Create(blog Blog) (*Blog, error) {
+	return s.CreateWithContext(context.Background(), blog)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *BlogServiceOp) CreateWithContext(ctx context.Context, blog Blog) (*Blog, error) {
 	path := fmt.Sprintf("%s.json", blogsBasePath)
 	wrappedData := BlogResource{Blog: &blog}
 	resource := new(BlogResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Blog, err
 }
 
 // Update an existing blog
 func (s *BlogServiceOp) Update(blog Blog) (*Blog, error) {
+	return s.UpdateWithContext(context.Background(), blog)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *BlogServiceOp) UpdateWithContext(ctx context.Context, blog Blog) (*Blog, error) {
 	path := fmt.Sprintf("%s/%d.json", blogsBasePath, blog.ID)
 	wrappedData := BlogResource{Blog: &blog}
 	resource := new(BlogResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Blog, err
 }
 
 // Delete an blog
 func (s *BlogServiceOp) Delete(blogId int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", blogsBasePath, blogId))
+	return s.DeleteWithContext(context.Background(), blogId)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *BlogServiceOp) DeleteWithContext(ctx context.Context, blogId int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", blogsBasePath, blogId))
 }
@@ -0,0 +1,508 @@
+package goshopify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultBulkOperationPollInterval is how often WaitWithContext polls the
+// status of a bulk operation when no interval is given.
+const DefaultBulkOperationPollInterval = 5 * time.Second
+
+// Statuses of a bulk operation
+const (
+	BulkOperationStatusCreated   = "CREATED"
+	BulkOperationStatusRunning   = "RUNNING"
+	BulkOperationStatusCompleted = "COMPLETED"
+	BulkOperationStatusCanceling = "CANCELING"
+	BulkOperationStatusCanceled  = "CANCELED"
+	BulkOperationStatusFailed    = "FAILED"
+	BulkOperationStatusExpired   = "EXPIRED"
+)
+
+// BulkOperationService is an interface for running bulk queries through the
+// GraphQL Admin API and reading their results.
+// See: https://help.shopify.com/api/guides/bulk-operations
+type BulkOperationService interface {
+	Run(string) (*BulkOperation, error)
+	Current() (*BulkOperation, error)
+	Cancel(string) (*BulkOperation, error)
+	Wait(time.Duration) (*BulkOperation, error)
+	Download(*BulkOperation) (io.ReadCloser, error)
+
+	RunWithContext(context.Context, string) (*BulkOperation, error)
+	CurrentWithContext(context.Context) (*BulkOperation, error)
+	CancelWithContext(context.Context, string) (*BulkOperation, error)
+	WaitWithContext(context.Context, time.Duration) (*BulkOperation, error)
+	DownloadWithContext(context.Context, *BulkOperation) (io.ReadCloser, error)
+}
+
+// BulkOperationServiceOp handles communication with the bulk operation
+// related queries of the GraphQL Admin API.
+type BulkOperationServiceOp struct {
+	client *Client
+}
+
+// BulkOperation represents a Shopify bulk operation. ObjectCount and FileSize
+// are strings since GraphQL encodes them as such.
+type BulkOperation struct {
+	ID             string     `json:"id"`
+	Status         string     `json:"status"`
+	ErrorCode      string     `json:"errorCode"`
+	CreatedAt      *time.Time `json:"createdAt"`
+	CompletedAt    *time.Time `json:"completedAt"`
+	ObjectCount    string     `json:"objectCount"`
+	FileSize       string     `json:"fileSize"`
+	URL            string     `json:"url"`
+	PartialDataURL string     `json:"partialDataUrl"`
+	Query          string     `json:"query"`
+}
+
+// Done reports whether the bulk operation has stopped running, successfully
+// or not.
+func (o *BulkOperation) Done() bool {
+	switch o.Status {
+	case BulkOperationStatusCompleted, BulkOperationStatusCanceled,
+		BulkOperationStatusFailed, BulkOperationStatusExpired:
+		return true
+	}
+	return false
+}
+
+// BulkOperationError occurs when waiting for a bulk operation that failed,
+// was canceled or expired.
+type BulkOperationError struct {
+	Operation *BulkOperation
+}
+
+func (e BulkOperationError) Error() string {
+	if e.Operation.ErrorCode != "" {
+		return fmt.Sprintf("bulk operation %s %s: %s", e.Operation.ID, strings.ToLower(e.Operation.Status), e.Operation.ErrorCode)
+	}
+	return fmt.Sprintf("bulk operation %s %s", e.Operation.ID, strings.ToLower(e.Operation.Status))
+}
+
+const bulkOperationFields = `id status errorCode createdAt completedAt objectCount fileSize url partialDataUrl query`
+
+// Run starts a bulk operation for the given query, which must have a single
+// top-level connection field. Only one bulk operation can run at a time per
+// shop and app.
+func (s *BulkOperationServiceOp) Run(query string) (*BulkOperation, error) {
+	return s.RunWithContext(context.Background(), query)
+}
+
+// RunWithContext is like Run but uses ctx for the request.
+func (s *BulkOperationServiceOp) RunWithContext(ctx context.Context, query string) (*BulkOperation, error) {
+	data := struct {
+		BulkOperationRunQuery struct {
+			BulkOperation *BulkOperation `json:"bulkOperation"`
+		} `json:"bulkOperationRunQuery"`
+	}{}
+	mutation := `mutation($query: String!) { bulkOperationRunQuery(query: $query) { bulkOperation { ` + bulkOperationFields + ` } userErrors { field message } } }`
+	_, err := s.client.GraphQL.QueryWithContext(ctx, mutation, map[string]interface{}{"query": query}, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data.BulkOperationRunQuery.BulkOperation, nil
+}
+
+// Current returns the most recent bulk operation of the app, or nil if there
+// is none.
+func (s *BulkOperationServiceOp) Current() (*BulkOperation, error) {
+	return s.CurrentWithContext(context.Background())
+}
+
+// CurrentWithContext is like Current but uses ctx for the request.
+func (s *BulkOperationServiceOp) CurrentWithContext(ctx context.Context) (*BulkOperation, error) {
+	data := struct {
+		CurrentBulkOperation *BulkOperation `json:"currentBulkOperation"`
+	}{}
+	_, err := s.client.GraphQL.QueryWithContext(ctx, `{ currentBulkOperation { `+bulkOperationFields+` } }`, nil, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data.CurrentBulkOperation, nil
+}
+
+// Cancel requests the cancellation of the bulk operation with the given ID.
+// The operation is canceling until it has stopped.
+func (s *BulkOperationServiceOp) Cancel(id string) (*BulkOperation, error) {
+	return s.CancelWithContext(context.Background(), id)
+}
+
+// CancelWithContext is like Cancel but uses ctx for the request.
+func (s *BulkOperationServiceOp) CancelWithContext(ctx context.Context, id string) (*BulkOperation, error) {
+	data := struct {
+		BulkOperationCancel struct {
+			BulkOperation *BulkOperation `json:"bulkOperation"`
+		} `json:"bulkOperationCancel"`
+	}{}
+	mutation := `mutation($id: ID!) { bulkOperationCancel(id: $id) { bulkOperation { ` + bulkOperationFields + ` } userErrors { field message } } }`
+	_, err := s.client.GraphQL.QueryWithContext(ctx, mutation, map[string]interface{}{"id": id}, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data.BulkOperationCancel.BulkOperation, nil
+}
+
+// Wait polls the current bulk operation at the given interval until it is
+// done. DefaultBulkOperationPollInterval is used if the interval is 0. A
+// BulkOperationError is returned along with the operation if it didn't
+// complete.
+func (s *BulkOperationServiceOp) Wait(interval time.Duration) (*BulkOperation, error) {
+	return s.WaitWithContext(context.Background(), interval)
+}
+
+// WaitWithContext is like Wait but stops waiting when ctx is done.
+func (s *BulkOperationServiceOp) WaitWithContext(ctx context.Context, interval time.Duration) (*BulkOperation, error) {
+	if interval <= 0 {
+		interval = DefaultBulkOperationPollInterval
+	}
+
+	for {
+		// Return the same error whether the request or the sleep noticed
+		// that ctx is done first
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		op, err := s.CurrentWithContext(ctx)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, err
+		}
+		if op == nil {
+			return nil, fmt.Errorf("no bulk operation to wait for")
+		}
+		if op.Done() {
+			if op.Status != BulkOperationStatusCompleted {
+				return op, BulkOperationError{Operation: op}
+			}
+			return op, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Download fetches the JSONL result of a completed bulk operation, or the
+// partial result of a failed one. The caller must close the returned body,
+// which is best read with a BulkReader. The body is empty if the operation
+// returned no objects.
+func (s *BulkOperationServiceOp) Download(op *BulkOperation) (io.ReadCloser, error) {
+	return s.DownloadWithContext(context.Background(), op)
+}
+
+// DownloadWithContext is like Download but uses ctx for the request.
+func (s *BulkOperationServiceOp) DownloadWithContext(ctx context.Context, op *BulkOperation) (io.ReadCloser, error) {
+	url := op.URL
+	if url == "" {
+		url = op.PartialDataURL
+	}
+	if url == "" {
+		// Shopify gives no URL when there are no results
+		return ioutil.NopCloser(strings.NewReader("")), nil
+	}
+
+	// The URL is signed so it is requested without the client's credentials
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Add("User-Agent", s.client.userAgent)
+
+	resp, err := s.client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, ResponseError{
+			Status:  resp.StatusCode,
+			Message: fmt.Sprintf("downloading bulk operation result: %s", resp.Status),
+		}
+	}
+	return resp.Body, nil
+}
+
+// BulkObject is an object from the JSONL result of a bulk operation, along
+// with the objects of its nested connections. Nested connections are output
+// as separate lines with a __parentId and are attached to their parent by
+// BulkReader.
+type BulkObject struct {
+	// ID is the GraphQL ID of the object, e.g. gid://shopify/Product/1
+	ID string
+
+	// Type is the GraphQL type from the ID, e.g. Product
+	Type string
+
+	// Data is the JSON of the object as output by Shopify
+	Data json.RawMessage
+
+	Children []*BulkObject
+}
+
+// ChildrenOfType returns the children of the given GraphQL type, e.g.
+// ProductVariant.
+func (o *BulkObject) ChildrenOfType(typ string) []*BulkObject {
+	var children []*BulkObject
+	for _, child := range o.Children {
+		if child.Type == typ {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Decode decodes the object into one of the REST models of this package,
+// e.g. Product. GraphQL field names are converted to snake case, GraphQL IDs
+// to numeric IDs and tags lists to comma separated strings. Children are not
+// decoded.
+func (o *BulkObject) Decode(v interface{}) error {
+	var fields interface{}
+	if err := json.Unmarshal(o.Data, &fields); err != nil {
+		return err
+	}
+	b, err := json.Marshal(graphQLToREST("", fields))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// BulkReader reads the JSONL result of a bulk operation one top-level object
+// at a time, without loading the whole result into memory. Shopify outputs
+// nested objects after their parent, which BulkReader relies on.
+type BulkReader struct {
+	scanner *bufio.Scanner
+	line    int
+	next    *BulkObject
+	nextErr error
+}
+
+// NewBulkReader returns a BulkReader that reads from r.
+func NewBulkReader(r io.Reader) *BulkReader {
+	scanner := bufio.NewScanner(r)
+	// Objects with long descriptions easily exceed the default limit
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return &BulkReader{scanner: scanner}
+}
+
+// bulkLine is the identifying part of a line of a bulk operation result.
+type bulkLine struct {
+	ID       string `json:"id"`
+	ParentID string `json:"__parentId"`
+}
+
+// readObject reads the next line, returning io.EOF at the end of the input.
+func (r *BulkReader) readObject() (*BulkObject, string, error) {
+	for r.scanner.Scan() {
+		r.line++
+		b := r.scanner.Bytes()
+		if len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+
+		line := bulkLine{}
+		if err := json.Unmarshal(b, &line); err != nil {
+			return nil, "", ResponseDecodingError{
+				Body:    append([]byte(nil), b...),
+				Message: fmt.Sprintf("line %d: %s", r.line, err),
+			}
+		}
+		obj := &BulkObject{
+			ID:   line.ID,
+			Type: graphQLIDType(line.ID),
+			Data: json.RawMessage(append([]byte(nil), b...)),
+		}
+		return obj, line.ParentID, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	return nil, "", io.EOF
+}
+
+// Next returns the next top-level object with its nested objects attached, or
+// io.EOF when there are no more objects.
+func (r *BulkReader) Next() (*BulkObject, error) {
+	root := r.next
+	r.next = nil
+	if root == nil {
+		if r.nextErr != nil {
+			return nil, r.nextErr
+		}
+		obj, parentID, err := r.readObject()
+		if err != nil {
+			return nil, err
+		}
+		if parentID != "" {
+			return nil, fmt.Errorf("line %d: parent %s of %s not found", r.line, parentID, obj.ID)
+		}
+		root = obj
+	}
+
+	objects := map[string]*BulkObject{root.ID: root}
+	for {
+		obj, parentID, err := r.readObject()
+		if err == io.EOF {
+			r.nextErr = err
+			return root, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if parentID == "" {
+			r.next = obj
+			return root, nil
+		}
+
+		parent, ok := objects[parentID]
+		if !ok {
+			return nil, fmt.Errorf("line %d: parent %s of %s not found", r.line, parentID, obj.ID)
+		}
+		parent.Children = append(parent.Children, obj)
+		if obj.ID != "" {
+			objects[obj.ID] = obj
+		}
+	}
+}
+
+// NextProduct returns the next product with the ProductVariant objects of its
+// variants connection, or io.EOF when there are no more products.
+func (r *BulkReader) NextProduct() (*Product, error) {
+	obj, err := r.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	product := new(Product)
+	if err := obj.Decode(product); err != nil {
+		return nil, err
+	}
+	for _, child := range obj.ChildrenOfType("ProductVariant") {
+		variant := Variant{}
+		if err := child.Decode(&variant); err != nil {
+			return nil, err
+		}
+		if variant.ProductID == 0 {
+			variant.ProductID = product.ID
+		}
+		product.Variants = append(product.Variants, variant)
+	}
+	return product, nil
+}
+
+// NextOrder returns the next order with the LineItem objects of its lineItems
+// connection, or io.EOF when there are no more orders.
+func (r *BulkReader) NextOrder() (*Order, error) {
+	obj, err := r.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	order := new(Order)
+	if err := obj.Decode(order); err != nil {
+		return nil, err
+	}
+	for _, child := range obj.ChildrenOfType("LineItem") {
+		lineItem := LineItem{}
+		if err := child.Decode(&lineItem); err != nil {
+			return nil, err
+		}
+		order.LineItems = append(order.LineItems, lineItem)
+	}
+	return order, nil
+}
+
+// graphQLIDType returns the type from a GraphQL ID, e.g. Product for
+// gid://shopify/Product/1.
+func graphQLIDType(id string) string {
+	parts := strings.Split(strings.TrimPrefix(id, "gid://shopify/"), "/")
+	if len(parts) != 2 || !strings.HasPrefix(id, "gid://shopify/") {
+		return ""
+	}
+	return parts[0]
+}
+
+// graphQLIDNumber returns the numeric ID from a GraphQL ID.
+func graphQLIDNumber(id string) (int, bool) {
+	if graphQLIDType(id) == "" {
+		return 0, false
+	}
+	id = id[strings.LastIndex(id, "/")+1:]
+	// Some IDs have a query string, e.g. for product images
+	if i := strings.Index(id, "?"); i >= 0 {
+		id = id[:i]
+	}
+	n, err := strconv.Atoi(id)
+	return n, err == nil
+}
+
+// graphQLToREST converts decoded GraphQL JSON to the shape of the REST API.
+// The key is the snake case name of the field holding the value.
+func graphQLToREST(key string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			if k == "__parentId" {
+				continue
+			}
+			k = snakeCase(k)
+			fields[k] = graphQLToREST(k, value)
+		}
+		return fields
+	case []interface{}:
+		if key == "tags" {
+			tags := make([]string, 0, len(v))
+			for _, tag := range v {
+				tags = append(tags, fmt.Sprint(tag))
+			}
+			return strings.Join(tags, ", ")
+		}
+		values := make([]interface{}, len(v))
+		for i, value := range v {
+			values[i] = graphQLToREST(key, value)
+		}
+		return values
+	case string:
+		if key == "id" || strings.HasSuffix(key, "_id") {
+			if n, ok := graphQLIDNumber(v); ok {
+				return n
+			}
+		}
+		return v
+	}
+	return v
+}
+
+// snakeCase converts a camel case GraphQL field name to snake case, e.g.
+// bodyHtml to body_html.
+func snakeCase(s string) string {
+	var b bytes.Buffer
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,310 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+// bulkOperationResponder responds to a currentBulkOperation query with an
+// operation with the given status.
+func bulkOperationResponder(status, url string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		op := BulkOperation{ID: "gid://shopify/BulkOperation/1", Status: status, URL: url}
+		b, _ := json.Marshal(map[string]interface{}{
+			"data": map[string]interface{}{"currentBulkOperation": op},
+		})
+		return httpmock.NewBytesResponse(200, b), nil
+	}
+}
+
+func TestBulkOperationRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var body map[string]interface{}
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(b, &body)
+			return httpmock.NewStringResponse(200, `{"data": {"bulkOperationRunQuery": {
+				"bulkOperation": {"id": "gid://shopify/BulkOperation/1", "status": "CREATED"},
+				"userErrors": []
+			}}}`), nil
+		})
+
+	query := `{ products { edges { node { id title } } } }`
+	op, err := client.BulkOperation.Run(query)
+	if err != nil {
+		t.Fatalf("BulkOperation.Run returned error: %v", err)
+	}
+
+	expected := &BulkOperation{ID: "gid://shopify/BulkOperation/1", Status: BulkOperationStatusCreated}
+	if !reflect.DeepEqual(op, expected) {
+		t.Errorf("BulkOperation.Run returned %+v, expected %+v", op, expected)
+	}
+
+	variables, _ := body["variables"].(map[string]interface{})
+	if variables["query"] != query {
+		t.Errorf("BulkOperation.Run posted variables %+v, expected query %v", variables, query)
+	}
+}
+
+func TestBulkOperationRunUserErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		httpmock.NewStringResponder(200, `{"data": {"bulkOperationRunQuery": {
+			"bulkOperation": null,
+			"userErrors": [{"field": ["query"], "message": "A bulk query operation for this app and shop is already in progress"}]
+		}}}`))
+
+	_, err := client.BulkOperation.Run(`{ products { edges { node { id } } } }`)
+	if _, ok := err.(GraphQLUserErrors); !ok {
+		t.Errorf("BulkOperation.Run err = %#v, expected GraphQLUserErrors", err)
+	}
+}
+
+func TestBulkOperationWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		sequenceResponder(&calls,
+			bulkOperationResponder(BulkOperationStatusCreated, ""),
+			bulkOperationResponder(BulkOperationStatusRunning, ""),
+			bulkOperationResponder(BulkOperationStatusCompleted, "https://storage.example.com/result.jsonl"),
+		))
+
+	op, err := client.BulkOperation.Wait(1)
+	if err != nil {
+		t.Fatalf("BulkOperation.Wait returned error: %v", err)
+	}
+	if op.Status != BulkOperationStatusCompleted || op.URL != "https://storage.example.com/result.jsonl" {
+		t.Errorf("BulkOperation.Wait returned %+v, expected a completed operation", op)
+	}
+	if calls != 3 {
+		t.Errorf("BulkOperation.Wait polled %d times, expected 3", calls)
+	}
+}
+
+func TestBulkOperationWaitFailed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		bulkOperationResponder(BulkOperationStatusFailed, ""))
+
+	op, err := client.BulkOperation.Wait(1)
+	expected := BulkOperationError{Operation: op}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("BulkOperation.Wait err = %#v, expected %#v", err, expected)
+	}
+	if err != nil && err.Error() != "bulk operation gid://shopify/BulkOperation/1 failed" {
+		t.Errorf("BulkOperationError.Error() = %v", err)
+	}
+}
+
+func TestBulkOperationWaitCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		bulkOperationResponder(BulkOperationStatusRunning, ""))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.BulkOperation.WaitWithContext(ctx, 1)
+	if err != context.Canceled {
+		t.Errorf("BulkOperation.WaitWithContext err = %v, expected %v", err, context.Canceled)
+	}
+
+	// Canceled while the request is in flight
+	ctx, cancel = context.WithCancel(context.Background())
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		func(req *http.Request) (*http.Response, error) {
+			cancel()
+			return nil, ctx.Err()
+		})
+	_, err = client.BulkOperation.WaitWithContext(ctx, 1)
+	if err != context.Canceled {
+		t.Errorf("BulkOperation.WaitWithContext err = %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestBulkOperationDownloadProducts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://storage.example.com/result.jsonl",
+		httpmock.NewBytesResponder(200, loadFixture("bulk_products.jsonl")))
+
+	body, err := client.BulkOperation.Download(&BulkOperation{URL: "https://storage.example.com/result.jsonl"})
+	if err != nil {
+		t.Fatalf("BulkOperation.Download returned error: %v", err)
+	}
+	defer body.Close()
+
+	var products []Product
+	reader := NewBulkReader(body)
+	for {
+		product, err := reader.NextProduct()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("BulkReader.NextProduct returned error: %v", err)
+		}
+		products = append(products, *product)
+	}
+
+	price1 := decimal.NewFromFloat(199)
+	price2 := decimal.NewFromFloat(209)
+	expected := []Product{
+		{
+			ID:          1,
+			Title:       "Burton Custom Freestyle 151",
+			BodyHTML:    "<strong>Good snowboard!</strong>",
+			ProductType: "Snowboard",
+			Tags:        "Barnes & Noble, John's Fav",
+			Variants: []Variant{
+				{ID: 11, ProductID: 1, Sku: "BCF-151-S", Price: &price1, InventoryQuantity: 10},
+				{ID: 12, ProductID: 1, Sku: "BCF-151-M", Price: &price2},
+			},
+		},
+		{ID: 2, Title: "Burton Custom Freestyle 152", ProductType: "Snowboard"},
+	}
+	if len(products) != len(expected) {
+		t.Fatalf("BulkReader.NextProduct returned %d products, expected %d", len(products), len(expected))
+	}
+	for i := range expected {
+		for j := range products[i].Variants {
+			// Compare decimals by value
+			if !products[i].Variants[j].Price.Equal(*expected[i].Variants[j].Price) {
+				t.Errorf("Variant price = %v, expected %v", products[i].Variants[j].Price, expected[i].Variants[j].Price)
+			}
+			products[i].Variants[j].Price = expected[i].Variants[j].Price
+		}
+		if !reflect.DeepEqual(products[i], expected[i]) {
+			t.Errorf("BulkReader.NextProduct returned %+v, expected %+v", products[i], expected[i])
+		}
+	}
+}
+
+func TestBulkOperationDownloadError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://storage.example.com/result.jsonl",
+		httpmock.NewStringResponder(403, `<Error><Code>ExpiredToken</Code></Error>`))
+
+	_, err := client.BulkOperation.Download(&BulkOperation{URL: "https://storage.example.com/result.jsonl"})
+	if responseErr, ok := err.(ResponseError); !ok || responseErr.Status != 403 {
+		t.Errorf("BulkOperation.Download err = %#v, expected a 403 ResponseError", err)
+	}
+}
+
+func TestBulkOperationDownloadNoResults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	body, err := client.BulkOperation.Download(&BulkOperation{Status: BulkOperationStatusCompleted})
+	if err != nil {
+		t.Fatalf("BulkOperation.Download returned error: %v", err)
+	}
+	if _, err := NewBulkReader(body).Next(); err != io.EOF {
+		t.Errorf("BulkReader.Next err = %v, expected %v", err, io.EOF)
+	}
+}
+
+func TestBulkReaderNextOrder(t *testing.T) {
+	reader := NewBulkReader(strings.NewReader(string(loadFixture("bulk_orders.jsonl"))))
+
+	order, err := reader.NextOrder()
+	if err != nil {
+		t.Fatalf("BulkReader.NextOrder returned error: %v", err)
+	}
+
+	expected := &Order{
+		ID:       450789469,
+		Name:     "#1001",
+		Email:    "bob.norman@hostmail.com",
+		Customer: &Customer{ID: 207119551},
+		LineItems: []LineItem{
+			{ID: 466157049, Title: "IPod Nano - 8gb", Quantity: 1, SKU: "IPOD2008GREEN"},
+			{ID: 518995019, Title: "IPod Nano - 8gb", Quantity: 2, SKU: "IPOD2008RED"},
+		},
+	}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("BulkReader.NextOrder returned %+v, expected %+v", order, expected)
+	}
+
+	if _, err := reader.NextOrder(); err != io.EOF {
+		t.Errorf("BulkReader.NextOrder err = %v, expected %v", err, io.EOF)
+	}
+}
+
+func TestBulkReaderNested(t *testing.T) {
+	input := `{"id":"gid://shopify/Product/1"}
+{"id":"gid://shopify/ProductVariant/11","__parentId":"gid://shopify/Product/1"}
+{"id":"gid://shopify/Metafield/111","__parentId":"gid://shopify/ProductVariant/11"}
+{"id":"gid://shopify/ProductImage/12?v=1","__parentId":"gid://shopify/Product/1"}
+`
+	obj, err := NewBulkReader(strings.NewReader(input)).Next()
+	if err != nil {
+		t.Fatalf("BulkReader.Next returned error: %v", err)
+	}
+
+	if obj.Type != "Product" || len(obj.Children) != 2 {
+		t.Fatalf("BulkReader.Next returned %+v, expected a product with 2 children", obj)
+	}
+	variants := obj.ChildrenOfType("ProductVariant")
+	if len(variants) != 1 || len(variants[0].Children) != 1 || variants[0].Children[0].Type != "Metafield" {
+		t.Errorf("BulkReader.Next variants = %+v, expected a variant with a metafield", variants)
+	}
+
+	image := Image{}
+	if err := obj.ChildrenOfType("ProductImage")[0].Decode(&image); err != nil || image.ID != 12 {
+		t.Errorf("BulkObject.Decode returned %+v, %v, expected image 12", image, err)
+	}
+}
+
+func TestBulkReaderErrors(t *testing.T) {
+	cases := []string{
+		`{"id":"gid://shopify/ProductVariant/11","__parentId":"gid://shopify/Product/1"}`,
+		`{"id":"gid://shopify/Product/1"}
+{"id":"gid://shopify/ProductVariant/11","__parentId":"gid://shopify/Product/2"}`,
+		`{"id":"gid://shopify/Product/1"}
+not json`,
+	}
+
+	for _, c := range cases {
+		if _, err := NewBulkReader(strings.NewReader(c)).Next(); err == nil {
+			t.Errorf("BulkReader.Next(%q) returned no error", c)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"id":                "id",
+		"bodyHtml":          "body_html",
+		"inventoryQuantity": "inventory_quantity",
+		"option1":           "option1",
+	}
+	for in, expected := range cases {
+		if actual := snakeCase(in); actual != expected {
+			t.Errorf("snakeCase(%q) = %q, expected %q", in, actual, expected)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -13,12 +14,24 @@ const customCollectionsResourceName = "collections"
 // See https://help.shopify.com/api/reference/customcollection
 type CustomCollectionService interface {
 	List(interface{}) ([]CustomCollection, error)
+	ListPage(interface{}) ([]CustomCollection, *Pagination, error)
+	ListAll(interface{}) ([]CustomCollection, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*CustomCollection, error)
 	Create(CustomCollection) (*CustomCollection, error)
 	Update(CustomCollection) (*CustomCollection, error)
 	Delete(int) error
 
+	ListWithContext(context.Context, interface{}) ([]CustomCollection, error)
+	ListPageWithContext(context.Context, interface{}) ([]CustomCollection, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]CustomCollection, error)
+	Iterate(context.Context, interface{}) <-chan CustomCollectionResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*CustomCollection, error)
+	CreateWithContext(context.Context, CustomCollection) (*CustomCollection, error)
+	UpdateWithContext(context.Context, CustomCollection) (*CustomCollection, error)
+	DeleteWithContext(context.Context, int) error
+
 	// MetafieldsService used for CustomCollection resource to communicate with Metafields resource
 	MetafieldsService
 }
@@ -55,84 +68,212 @@ type CustomCollectionsResource struct {
 	Collections []CustomCollection `json:"custom_collections"`
 }
 
+// CustomCollectionResult is a custom collection or an error from Iterate.
+type CustomCollectionResult struct {
+	CustomCollection CustomCollection
+	Err              error
+}
+
 // List custom collections
 func (s *CustomCollectionServiceOp) List(options interface{}) ([]CustomCollection, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *CustomCollectionServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]CustomCollection, error) {
 	path := fmt.Sprintf("%s.json", customCollectionsBasePath)
 	resource := new(CustomCollectionsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Collections, err
 }
 
+// ListPage lists a page of custom collections along with its pagination
+func (s *CustomCollectionServiceOp) ListPage(options interface{}) ([]CustomCollection, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *CustomCollectionServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]CustomCollection, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", customCollectionsBasePath)
+	resource := new(CustomCollectionsResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Collections, pagination, err
+}
+
+// ListAll lists all custom collections, following the pagination from the given options
+func (s *CustomCollectionServiceOp) ListAll(options interface{}) ([]CustomCollection, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *CustomCollectionServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]CustomCollection, error) {
+	var collector []CustomCollection
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		customCollections, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, customCollections...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all custom collections over the returned channel, fetching the pages
+// lazily. The channel is closed after the last custom collection or after a result
+// with an error. Cancel ctx to stop early.
+func (s *CustomCollectionServiceOp) Iterate(ctx context.Context, options interface{}) <-chan CustomCollectionResult {
+	results := make(chan CustomCollectionResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			customCollections, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, customCollection := range customCollections {
+				select {
+				case results <- CustomCollectionResult{CustomCollection: customCollection}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- CustomCollectionResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count custom collections
 func (s *CustomCollectionServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *CustomCollectionServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", customCollectionsBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual custom collection
 func (s *CustomCollectionServiceOp) Get(collectionID int, options interface{}) (*CustomCollection, error) {
+	return s.GetWithContext(context.Background(), collectionID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *CustomCollectionServiceOp) GetWithContext(ctx context.Context, collectionID int, options interface{}) (*CustomCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collectionID)
 	resource := new(CustomCollectionResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Collection, err
 }
 
 // Create a new custom collection
 // See Image for the details of the Image creation for a collection.
 func (s *CustomCollectionServiceOp) Create(collection CustomCollection) (*CustomCollection, error) {
+	return s.CreateWithContext(context.Background(), collection)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *CustomCollectionServiceOp) CreateWithContext(ctx context.Context, collection CustomCollection) (*CustomCollection, error) {
 	path := fmt.Sprintf("%s.json", customCollectionsBasePath)
 	wrappedData := CustomCollectionResource{Collection: &collection}
 	resource := new(CustomCollectionResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Collection, err
 }
 
 // Update an existing custom collection
 func (s *CustomCollectionServiceOp) Update(collection CustomCollection) (*CustomCollection, error) {
+	return s.UpdateWithContext(context.Background(), collection)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *CustomCollectionServiceOp) UpdateWithContext(ctx context.Context, collection CustomCollection) (*CustomCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collection.ID)
 	wrappedData := CustomCollectionResource{Collection: &collection}
 	resource := new(CustomCollectionResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Collection, err
 }
 
 // Delete an existing custom collection.
 func (s *CustomCollectionServiceOp) Delete(collectionID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collectionID))
+	return s.DeleteWithContext(context.Background(), collectionID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *CustomCollectionServiceOp) DeleteWithContext(ctx context.Context, collectionID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", customCollectionsBasePath, collectionID))
 }
 
 // List metafields for a custom collection
 func (s *CustomCollectionServiceOp) ListMetafields(customCollectionID int, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), customCollectionID, options)
+}
+
+// ListMetafieldsWithContext is like ListMetafields but uses ctx for the request.
+func (s *CustomCollectionServiceOp) ListMetafieldsWithContext(ctx context.Context, customCollectionID int, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceID: customCollectionID}
-	return metafieldService.List(options)
+	return metafieldService.ListWithContext(ctx, options)
 }
 
 // Count metafields for a custom collection
 func (s *CustomCollectionServiceOp) CountMetafields(customCollectionID int, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), customCollectionID, options)
+}
+
+// CountMetafieldsWithContext is like CountMetafields but uses ctx for the request.
+func (s *CustomCollectionServiceOp) CountMetafieldsWithContext(ctx context.Context, customCollectionID int, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceID: customCollectionID}
-	return metafieldService.Count(options)
+	return metafieldService.CountWithContext(ctx, options)
 }
 
 // Get individual metafield for a custom collection
 func (s *CustomCollectionServiceOp) GetMetafield(customCollectionID int, metafieldID int, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), customCollectionID, metafieldID, options)
+}
+
+// GetMetafieldWithContext is like GetMetafield but uses ctx for the request.
+func (s *CustomCollectionServiceOp) GetMetafieldWithContext(ctx context.Context, customCollectionID int, metafieldID int, options interface{}) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceID: customCollectionID}
-	return metafieldService.Get(metafieldID, options)
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
 }
 
 // Create a new metafield for a custom collection
 func (s *CustomCollectionServiceOp) CreateMetafield(customCollectionID int, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), customCollectionID, metafield)
+}
+
+// CreateMetafieldWithContext is like CreateMetafield but uses ctx for the request.
+func (s *CustomCollectionServiceOp) CreateMetafieldWithContext(ctx context.Context, customCollectionID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceID: customCollectionID}
-	return metafieldService.Create(metafield)
+	return metafieldService.CreateWithContext(ctx, metafield)
 }
 
 // Update an existing metafield for a custom collection
 func (s *CustomCollectionServiceOp) UpdateMetafield(customCollectionID int, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), customCollectionID, metafield)
+}
+
+// UpdateMetafieldWithContext is like UpdateMetafield but uses ctx for the request.
+func (s *CustomCollectionServiceOp) UpdateMetafieldWithContext(ctx context.Context, customCollectionID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceID: customCollectionID}
-	return metafieldService.Update(metafield)
+	return metafieldService.UpdateWithContext(ctx, metafield)
 }
 
 // // Delete an existing metafield for a custom collection
 func (s *CustomCollectionServiceOp) DeleteMetafield(customCollectionID int, metafieldID int) error {
+	return s.DeleteMetafieldWithContext(context.Background(), customCollectionID, metafieldID)
+}
+
+// DeleteMetafieldWithContext is like DeleteMetafield but uses ctx for the request.
+func (s *CustomCollectionServiceOp) DeleteMetafieldWithContext(ctx context.Context, customCollectionID int, metafieldID int) error {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customCollectionsResourceName, resourceID: customCollectionID}
-	return metafieldService.Delete(metafieldID)
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
 }
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,6 +16,8 @@ const customersResourceName = "customers"
 // See: https://help.shopify.com/api/reference/customer
 type CustomerService interface {
 	List(interface{}) ([]Customer, error)
+	ListPage(interface{}) ([]Customer, *Pagination, error)
+	ListAll(interface{}) ([]Customer, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Customer, error)
 	Search(interface{}) ([]Customer, error)
@@ -22,6 +25,17 @@ type CustomerService interface {
 	Update(Customer) (*Customer, error)
 	Delete(int) error
 
+	ListWithContext(context.Context, interface{}) ([]Customer, error)
+	ListPageWithContext(context.Context, interface{}) ([]Customer, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Customer, error)
+	Iterate(context.Context, interface{}) <-chan CustomerResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Customer, error)
+	SearchWithContext(context.Context, interface{}) ([]Customer, error)
+	CreateWithContext(context.Context, Customer) (*Customer, error)
+	UpdateWithContext(context.Context, Customer) (*Customer, error)
+	DeleteWithContext(context.Context, int) error
+
 	// MetafieldsService used for Customer resource to communicate with Metafields resource
 	MetafieldsService
 }
@@ -67,6 +81,12 @@ type CustomersResource struct {
 	Customers []Customer `json:"customers"`
 }
 
+// CustomerResult is a customer or an error from Iterate.
+type CustomerResult struct {
+	Customer Customer
+	Err      error
+}
+
 // Represents the options available when searching for a customer
 type CustomerSearchOptions struct {
 	Page   int    `url:"page,omitempty"`
@@ -78,90 +98,217 @@ type CustomerSearchOptions struct {
 
 // List customers
 func (s *CustomerServiceOp) List(options interface{}) ([]Customer, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *CustomerServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Customer, error) {
 	path := fmt.Sprintf("%s.json", customersBasePath)
 	resource := new(CustomersResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Customers, err
 }
 
+// ListPage lists a page of customers along with its pagination
+func (s *CustomerServiceOp) ListPage(options interface{}) ([]Customer, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *CustomerServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Customer, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", customersBasePath)
+	resource := new(CustomersResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Customers, pagination, err
+}
+
+// ListAll lists all customers, following the pagination from the given options
+func (s *CustomerServiceOp) ListAll(options interface{}) ([]Customer, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *CustomerServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Customer, error) {
+	var collector []Customer
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		customers, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, customers...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all customers over the returned channel, fetching the pages
+// lazily. The channel is closed after the last customer or after a result
+// with an error. Cancel ctx to stop early.
+func (s *CustomerServiceOp) Iterate(ctx context.Context, options interface{}) <-chan CustomerResult {
+	results := make(chan CustomerResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			customers, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, customer := range customers {
+				select {
+				case results <- CustomerResult{Customer: customer}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- CustomerResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count customers
 func (s *CustomerServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *CustomerServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", customersBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get customer
 func (s *CustomerServiceOp) Get(customerID int, options interface{}) (*Customer, error) {
+	return s.GetWithContext(context.Background(), customerID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *CustomerServiceOp) GetWithContext(ctx context.Context, customerID int, options interface{}) (*Customer, error) {
 	path := fmt.Sprintf("%s/%v.json", customersBasePath, customerID)
 	resource := new(CustomerResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Customer, err
 }
 
 // Create a new customer
 func (s *CustomerServiceOp) Create(customer Customer) (*Customer, error) {
+	return s.CreateWithContext(context.Background(), customer)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *CustomerServiceOp) CreateWithContext(ctx context.Context, customer Customer) (*Customer, error) {
 	path := fmt.Sprintf("%s.json", customersBasePath)
 	wrappedData := CustomerResource{Customer: &customer}
 	resource := new(CustomerResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Customer, err
 }
 
 // Update an existing customer
 func (s *CustomerServiceOp) Update(customer Customer) (*Customer, error) {
+	return s.UpdateWithContext(context.Background(), customer)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *CustomerServiceOp) UpdateWithContext(ctx context.Context, customer Customer) (*Customer, error) {
 	path := fmt.Sprintf("%s/%d.json", customersBasePath, customer.ID)
 	wrappedData := CustomerResource{Customer: &customer}
 	resource := new(CustomerResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Customer, err
 }
 
 // Delete an existing customer
 func (s *CustomerServiceOp) Delete(customerID int) error {
+	return s.DeleteWithContext(context.Background(), customerID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *CustomerServiceOp) DeleteWithContext(ctx context.Context, customerID int) error {
 	path := fmt.Sprintf("%s/%d.json", customersBasePath, customerID)
-	return s.client.Delete(path)
+	return s.client.DeleteWithContext(ctx, path)
 }
 
 // Search customers
 func (s *CustomerServiceOp) Search(options interface{}) ([]Customer, error) {
+	return s.SearchWithContext(context.Background(), options)
+}
+
+// SearchWithContext is like Search but uses ctx for the request.
+func (s *CustomerServiceOp) SearchWithContext(ctx context.Context, options interface{}) ([]Customer, error) {
 	path := fmt.Sprintf("%s/search.json", customersBasePath)
 	resource := new(CustomersResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Customers, err
 }
 
 // List metafields for a customer
 func (s *CustomerServiceOp) ListMetafields(customerID int, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), customerID, options)
+}
+
+// ListMetafieldsWithContext is like ListMetafields but uses ctx for the request.
+func (s *CustomerServiceOp) ListMetafieldsWithContext(ctx context.Context, customerID int, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceID: customerID}
-	return metafieldService.List(options)
+	return metafieldService.ListWithContext(ctx, options)
 }
 
 // Count metafields for a customer
 func (s *CustomerServiceOp) CountMetafields(customerID int, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), customerID, options)
+}
+
+// CountMetafieldsWithContext is like CountMetafields but uses ctx for the request.
+func (s *CustomerServiceOp) CountMetafieldsWithContext(ctx context.Context, customerID int, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceID: customerID}
-	return metafieldService.Count(options)
+	return metafieldService.CountWithContext(ctx, options)
 }
 
 // Get individual metafield for a customer
 func (s *CustomerServiceOp) GetMetafield(customerID int, metafieldID int, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), customerID, metafieldID, options)
+}
+
+// GetMetafieldWithContext is like GetMetafield but uses ctx for the request.
+func (s *CustomerServiceOp) GetMetafieldWithContext(ctx context.Context, customerID int, metafieldID int, options interface{}) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceID: customerID}
-	return metafieldService.Get(metafieldID, options)
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
 }
 
 // Create a new metafield for a customer
 func (s *CustomerServiceOp) CreateMetafield(customerID int, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), customerID, metafield)
+}
+
+// CreateMetafieldWithContext is like CreateMetafield but uses ctx for the request.
+func (s *CustomerServiceOp) CreateMetafieldWithContext(ctx context.Context, customerID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceID: customerID}
-	return metafieldService.Create(metafield)
+	return metafieldService.CreateWithContext(ctx, metafield)
 }
 
 // Update an existing metafield for a customer
 func (s *CustomerServiceOp) UpdateMetafield(customerID int, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), customerID, metafield)
+}
+
+// UpdateMetafieldWithContext is like UpdateMetafield but uses ctx for the request.
+func (s *CustomerServiceOp) UpdateMetafieldWithContext(ctx context.Context, customerID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceID: customerID}
-	return metafieldService.Update(metafield)
+	return metafieldService.UpdateWithContext(ctx, metafield)
 }
 
 // // Delete an existing metafield for a customer
 func (s *CustomerServiceOp) DeleteMetafield(customerID int, metafieldID int) error {
+	return s.DeleteMetafieldWithContext(context.Background(), customerID, metafieldID)
+}
+
+// DeleteMetafieldWithContext is like DeleteMetafield but uses ctx for the request.
+func (s *CustomerServiceOp) DeleteMetafieldWithContext(ctx context.Context, customerID int, metafieldID int) error {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: customersResourceName, resourceID: customerID}
-	return metafieldService.Delete(metafieldID)
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
 }
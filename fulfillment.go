@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -17,6 +18,15 @@ type FulfillmentService interface {
 	Complete(int) (*Fulfillment, error)
 	Transition(int) (*Fulfillment, error)
 	Cancel(int) (*Fulfillment, error)
+
+	ListWithContext(context.Context, interface{}) ([]Fulfillment, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Fulfillment, error)
+	CreateWithContext(context.Context, Fulfillment) (*Fulfillment, error)
+	UpdateWithContext(context.Context, Fulfillment) (*Fulfillment, error)
+	CompleteWithContext(context.Context, int) (*Fulfillment, error)
+	TransitionWithContext(context.Context, int) (*Fulfillment, error)
+	CancelWithContext(context.Context, int) (*Fulfillment, error)
 }
 
 // FulfillmentsService is an interface for other Shopify resources
@@ -31,6 +41,15 @@ type FulfillmentsService interface {
 	CompleteFulfillment(int, int) (*Fulfillment, error)
 	TransitionFulfillment(int, int) (*Fulfillment, error)
 	CancelFulfillment(int, int) (*Fulfillment, error)
+
+	ListFulfillmentsWithContext(context.Context, int, interface{}) ([]Fulfillment, error)
+	CountFulfillmentsWithContext(context.Context, int, interface{}) (int, error)
+	GetFulfillmentWithContext(context.Context, int, int, interface{}) (*Fulfillment, error)
+	CreateFulfillmentWithContext(context.Context, int, Fulfillment) (*Fulfillment, error)
+	UpdateFulfillmentWithContext(context.Context, int, Fulfillment) (*Fulfillment, error)
+	CompleteFulfillmentWithContext(context.Context, int, int) (*Fulfillment, error)
+	TransitionFulfillmentWithContext(context.Context, int, int) (*Fulfillment, error)
+	CancelFulfillmentWithContext(context.Context, int, int) (*Fulfillment, error)
 }
 
 // FulfillmentServiceOp handles communication with the fulfillment
@@ -79,72 +98,112 @@ type FulfillmentsResource struct {
 
 // List fulfillments
 func (s *FulfillmentServiceOp) List(options interface{}) ([]Fulfillment, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *FulfillmentServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
 	resource := new(FulfillmentsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Fulfillments, err
 }
 
 // Count fulfillments
 func (s *FulfillmentServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *FulfillmentServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/count.json", prefix)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual fulfillment
 func (s *FulfillmentServiceOp) Get(fulfillmentID int, options interface{}) (*Fulfillment, error) {
+	return s.GetWithContext(context.Background(), fulfillmentID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *FulfillmentServiceOp) GetWithContext(ctx context.Context, fulfillmentID int, options interface{}) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, fulfillmentID)
 	resource := new(FulfillmentResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Fulfillment, err
 }
 
 // Create a new fulfillment
 func (s *FulfillmentServiceOp) Create(fulfillment Fulfillment) (*Fulfillment, error) {
+	return s.CreateWithContext(context.Background(), fulfillment)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *FulfillmentServiceOp) CreateWithContext(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
 	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Fulfillment, err
 }
 
 // Update an existing fulfillment
 func (s *FulfillmentServiceOp) Update(fulfillment Fulfillment) (*Fulfillment, error) {
+	return s.UpdateWithContext(context.Background(), fulfillment)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *FulfillmentServiceOp) UpdateWithContext(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, fulfillment.ID)
 	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
 	resource := new(FulfillmentResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Fulfillment, err
 }
 
 // Complete an existing fulfillment
 func (s *FulfillmentServiceOp) Complete(fulfillmentID int) (*Fulfillment, error) {
+	return s.CompleteWithContext(context.Background(), fulfillmentID)
+}
+
+// CompleteWithContext is like Complete but uses ctx for the request.
+func (s *FulfillmentServiceOp) CompleteWithContext(ctx context.Context, fulfillmentID int) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d/complete.json", prefix, fulfillmentID)
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
 	return resource.Fulfillment, err
 }
 
 // Transition an existing fulfillment
 func (s *FulfillmentServiceOp) Transition(fulfillmentID int) (*Fulfillment, error) {
+	return s.TransitionWithContext(context.Background(), fulfillmentID)
+}
+
+// TransitionWithContext is like Transition but uses ctx for the request.
+func (s *FulfillmentServiceOp) TransitionWithContext(ctx context.Context, fulfillmentID int) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d/open.json", prefix, fulfillmentID)
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
 	return resource.Fulfillment, err
 }
 
 // Cancel an existing fulfillment
 func (s *FulfillmentServiceOp) Cancel(fulfillmentID int) (*Fulfillment, error) {
+	return s.CancelWithContext(context.Background(), fulfillmentID)
+}
+
+// CancelWithContext is like Cancel but uses ctx for the request.
+func (s *FulfillmentServiceOp) CancelWithContext(ctx context.Context, fulfillmentID int) (*Fulfillment, error) {
 	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d/cancel.json", prefix, fulfillmentID)
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
 	return resource.Fulfillment, err
 }
@@ -0,0 +1,107 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// GDPRCustomer is the customer a GDPR webhook is about.
+type GDPRCustomer struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// GDPRDataRequest identifies a customer's request for their data.
+type GDPRDataRequest struct {
+	ID int `json:"id"`
+}
+
+// CustomersDataRequest is the payload of the customers/data_request webhook,
+// sent when a customer requests their data from a shop.
+// See: https://help.shopify.com/api/guides/gdpr-resources
+type CustomersDataRequest struct {
+	ShopID          int             `json:"shop_id"`
+	ShopDomain      string          `json:"shop_domain"`
+	Customer        GDPRCustomer    `json:"customer"`
+	OrdersRequested []int           `json:"orders_requested"`
+	DataRequest     GDPRDataRequest `json:"data_request"`
+}
+
+// CustomersRedact is the payload of the customers/redact webhook, sent when
+// a shop requests the deletion of a customer's data.
+type CustomersRedact struct {
+	ShopID         int          `json:"shop_id"`
+	ShopDomain     string       `json:"shop_domain"`
+	Customer       GDPRCustomer `json:"customer"`
+	OrdersToRedact []int        `json:"orders_to_redact"`
+}
+
+// ShopRedact is the payload of the shop/redact webhook, sent 48 hours after a
+// shop uninstalled the app to request the deletion of its data.
+type ShopRedact struct {
+	ShopID     int    `json:"shop_id"`
+	ShopDomain string `json:"shop_domain"`
+}
+
+// GDPRHandler processes the mandatory GDPR webhooks that every app must
+// handle. Returning an error makes Shopify retry the webhook later.
+type GDPRHandler interface {
+	// DataRequest must send the data the app stores about the customer to
+	// the shop.
+	DataRequest(ctx context.Context, delivery WebhookDelivery, request *CustomersDataRequest) error
+
+	// RedactCustomer must erase the data the app stores about the customer.
+	RedactCustomer(ctx context.Context, delivery WebhookDelivery, redact *CustomersRedact) error
+
+	// RedactShop must erase the data the app stores about the shop.
+	RedactShop(ctx context.Context, delivery WebhookDelivery, redact *ShopRedact) error
+}
+
+// NewGDPRWebhookHandler returns a WebhookHandler for the app that routes the
+// GDPR webhooks to the given GDPRHandler. More topics can be added to it.
+func NewGDPRWebhookHandler(app App, gdpr GDPRHandler) *WebhookHandler {
+	h := NewWebhookHandler(app)
+	h.HandleGDPR(gdpr)
+	return h
+}
+
+// HandleGDPR registers callbacks for the customers/data_request,
+// customers/redact and shop/redact topics that pass the decoded payloads to
+// the given GDPRHandler. Payloads for another shop than the one in the
+// X-Shopify-Shop-Domain header are rejected.
+func (h *WebhookHandler) HandleGDPR(gdpr GDPRHandler) {
+	h.HandleFunc(WebhookTopicCustomersDataRequest, func(ctx context.Context, d WebhookDelivery) error {
+		request := new(CustomersDataRequest)
+		if err := decodeGDPRPayload(d, request, &request.ShopDomain); err != nil {
+			return err
+		}
+		return gdpr.DataRequest(ctx, d, request)
+	})
+	h.HandleFunc(WebhookTopicCustomersRedact, func(ctx context.Context, d WebhookDelivery) error {
+		redact := new(CustomersRedact)
+		if err := decodeGDPRPayload(d, redact, &redact.ShopDomain); err != nil {
+			return err
+		}
+		return gdpr.RedactCustomer(ctx, d, redact)
+	})
+	h.HandleFunc(WebhookTopicShopRedact, func(ctx context.Context, d WebhookDelivery) error {
+		redact := new(ShopRedact)
+		if err := decodeGDPRPayload(d, redact, &redact.ShopDomain); err != nil {
+			return err
+		}
+		return gdpr.RedactShop(ctx, d, redact)
+	})
+}
+
+// decodeGDPRPayload decodes the payload of a GDPR webhook and checks that the
+// shop domain it was decoded with matches the delivery.
+func decodeGDPRPayload(d WebhookDelivery, payload interface{}, shopDomain *string) error {
+	if err := d.Decode(payload); err != nil {
+		return webhookPayloadError{err}
+	}
+	if *shopDomain != d.ShopDomain {
+		return webhookPayloadError{fmt.Errorf("payload is for shop %q, not %q", *shopDomain, d.ShopDomain)}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// testGDPRHandler records the GDPR webhooks it receives.
+type testGDPRHandler struct {
+	dataRequest    *CustomersDataRequest
+	customerRedact *CustomersRedact
+	shopRedact     *ShopRedact
+	err            error
+}
+
+func (h *testGDPRHandler) DataRequest(ctx context.Context, d WebhookDelivery, request *CustomersDataRequest) error {
+	h.dataRequest = request
+	return h.err
+}
+
+func (h *testGDPRHandler) RedactCustomer(ctx context.Context, d WebhookDelivery, redact *CustomersRedact) error {
+	h.customerRedact = redact
+	return h.err
+}
+
+func (h *testGDPRHandler) RedactShop(ctx context.Context, d WebhookDelivery, redact *ShopRedact) error {
+	h.shopRedact = redact
+	return h.err
+}
+
+func TestGDPRWebhookHandler(t *testing.T) {
+	gdpr := &testGDPRHandler{}
+	handler := NewGDPRWebhookHandler(App{ApiSecret: "hush"}, gdpr)
+
+	deliveries := map[WebhookTopic]string{
+		WebhookTopicCustomersDataRequest: `{"shop_id": 954889, "shop_domain": "fooshop.myshopify.com", "orders_requested": [299938], "customer": {"id": 191167, "email": "john@email.com"}, "data_request": {"id": 9999}}`,
+		WebhookTopicCustomersRedact:      `{"shop_id": 954889, "shop_domain": "fooshop.myshopify.com", "orders_to_redact": [299938, 280263], "customer": {"id": 191167, "email": "john@email.com"}}`,
+		WebhookTopicShopRedact:           `{"shop_id": 954889, "shop_domain": "fooshop.myshopify.com"}`,
+	}
+	for topic, body := range deliveries {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, webhookRequest("hush", string(topic), body))
+		if w.Code != http.StatusOK {
+			t.Errorf("WebhookHandler responded %d to %s, expected %d", w.Code, topic, http.StatusOK)
+		}
+	}
+
+	customer := GDPRCustomer{ID: 191167, Email: "john@email.com"}
+	expectedDataRequest := &CustomersDataRequest{
+		ShopID:          954889,
+		ShopDomain:      "fooshop.myshopify.com",
+		Customer:        customer,
+		OrdersRequested: []int{299938},
+		DataRequest:     GDPRDataRequest{ID: 9999},
+	}
+	if !reflect.DeepEqual(gdpr.dataRequest, expectedDataRequest) {
+		t.Errorf("GDPRHandler.DataRequest got %+v, expected %+v", gdpr.dataRequest, expectedDataRequest)
+	}
+
+	expectedCustomerRedact := &CustomersRedact{
+		ShopID:         954889,
+		ShopDomain:     "fooshop.myshopify.com",
+		Customer:       customer,
+		OrdersToRedact: []int{299938, 280263},
+	}
+	if !reflect.DeepEqual(gdpr.customerRedact, expectedCustomerRedact) {
+		t.Errorf("GDPRHandler.RedactCustomer got %+v, expected %+v", gdpr.customerRedact, expectedCustomerRedact)
+	}
+
+	expectedShopRedact := &ShopRedact{ShopID: 954889, ShopDomain: "fooshop.myshopify.com"}
+	if !reflect.DeepEqual(gdpr.shopRedact, expectedShopRedact) {
+		t.Errorf("GDPRHandler.RedactShop got %+v, expected %+v", gdpr.shopRedact, expectedShopRedact)
+	}
+}
+
+func TestGDPRWebhookHandlerErrors(t *testing.T) {
+	gdpr := &testGDPRHandler{}
+	handler := NewGDPRWebhookHandler(App{ApiSecret: "hush"}, gdpr)
+
+	cases := []struct {
+		name     string
+		req      *http.Request
+		err      error
+		expected int
+	}{
+		{"invalid hmac", webhookRequest("wrong", "shop/redact", `{"shop_domain": "fooshop.myshopify.com"}`), nil, http.StatusUnauthorized},
+		{"other shop", webhookRequest("hush", "shop/redact", `{"shop_domain": "barshop.myshopify.com"}`), nil, http.StatusBadRequest},
+		{"handler error", webhookRequest("hush", "shop/redact", `{"shop_domain": "fooshop.myshopify.com"}`), errors.New("database is down"), http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		gdpr.err = c.err
+		gdpr.shopRedact = nil
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, c.req)
+		if w.Code != c.expected {
+			t.Errorf("WebhookHandler responded %d for %s, expected %d", w.Code, c.name, c.expected)
+		}
+		if c.expected == http.StatusBadRequest && gdpr.shopRedact != nil {
+			t.Errorf("GDPRHandler.RedactShop was called for %s", c.name)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package goshopify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -12,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -29,6 +31,11 @@ type App struct {
 	RedirectUrl string
 	Scope       string
 	Password    string
+
+	// ShopDomainSuffixes are the domains shops may be under, e.g.
+	// "myshopify.io" for development stores. DefaultShopDomainSuffixes are
+	// used if it is empty.
+	ShopDomainSuffixes []string
 }
 
 // Client manages communication with the Shopify API.
@@ -47,6 +54,30 @@ type Client struct {
 	// A permanent access token
 	token string
 
+	// Retry configures retries of rate limited and failed requests. Requests
+	// are not retried when it is nil.
+	Retry *RetryPolicy
+
+	// RateLimiter, if set, is updated from the call limit of every response
+	// and makes the client wait before sending requests that would exceed
+	// the shop's limit. It can be shared between clients for the same shop.
+	RateLimiter *RateLimiter
+
+	// GraphQLLimiter, if set, makes GraphQL queries wait for room in the
+	// shop's cost bucket and retries throttled queries.
+	GraphQLLimiter *GraphQLLimiter
+
+	// State from the last response, guarded by mu
+	mu              sync.Mutex
+	callLimit       CallLimit
+	responseVersion string
+
+	// Settings from the client options
+	logger             Logger
+	apiVersion         string
+	userAgent          string
+	deprecationHandler DeprecationHandler
+
 	// Services used for communicating with the API
 	Product                    ProductService
 	CustomCollection           CustomCollectionService
@@ -67,6 +98,9 @@ type Client struct {
 	ApplicationCharge          ApplicationChargeService
 	Redirect                   RedirectService
 	Page                       PageService
+	GraphQL                    GraphQLService
+	BulkOperation              BulkOperationService
+	AccessScope                AccessScopeService
 }
 
 // A general response error that follows a similar layout to Shopify's response
@@ -115,12 +149,25 @@ type RateLimitError struct {
 // be resolved to the BaseURL of the Client. Relative URLS should always be
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
+// If the client has an API version, relative "admin/" paths are rewritten to
+// the versioned "admin/api/{version}/" paths.
 func (c *Client) NewRequest(method, urlStr string, body, options interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body, options)
+}
+
+// NewRequestWithContext is like NewRequest but attaches ctx to the request so
+// that cancellation and deadlines are honoured by the underlying http.Client.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body, options interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
 
+	// Use the versioned path for relative urls
+	if !rel.IsAbs() && rel.Host == "" {
+		rel.Path = versionedPath(rel.Path, c.apiVersion)
+	}
+
 	// Make the full url based on the relative path
 	u := c.baseURL.ResolveReference(rel)
 
@@ -153,10 +200,11 @@ func (c *Client) NewRequest(method, urlStr string, body, options interface{}) (*
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
-	req.Header.Add("User-Agent", UserAgent)
+	req.Header.Add("User-Agent", c.userAgent)
 	if c.token != "" {
 		req.Header.Add("X-Shopify-Access-Token", c.token)
 	} else if c.app.Password != "" {
@@ -167,13 +215,42 @@ func (c *Client) NewRequest(method, urlStr string, body, options interface{}) (*
 
 // Returns a new Shopify API client with an already authenticated shopname and
 // token. The shopName parameter is the shop's myshopify domain,
-// e.g. "theshop.myshopify.com", or simply "theshop"
+// e.g. "theshop.myshopify.com", or simply "theshop". The shop is not
+// validated, so that callers relying on it keep working; use
+// NewClientWithOptions, which returns a ShopDomainError for an invalid shop,
+// when the shop name comes from a request.
 func NewClient(app App, shopName, token string) *Client {
-	httpClient := http.DefaultClient
-
 	baseURL, _ := url.Parse(ShopBaseUrl(shopName))
+	if shopDomain, err := app.ShopDomain(shopName); err == nil {
+		baseURL = &url.URL{Scheme: "https", Host: shopDomain}
+	}
+	return newClient(app, baseURL, token)
+}
+
+// NewClientWithOptions is like NewClient but the client can be configured
+// with options such as WithHTTPClient and WithRetry. A ShopDomainError is
+// returned if the shop is not valid, see App.ShopDomain, or another error if
+// any of the options is invalid.
+func NewClientWithOptions(app App, shopName, token string, opts ...Option) (*Client, error) {
+	shopDomain, err := app.ShopDomain(shopName)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newClient(app, &url.URL{Scheme: "https", Host: shopDomain}, token)
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
 
-	c := &Client{Client: httpClient, app: app, baseURL: baseURL, token: token}
+func newClient(app App, baseURL *url.URL, token string) *Client {
+	httpClient := http.DefaultClient
+
+	c := &Client{Client: httpClient, app: app, baseURL: baseURL, token: token, userAgent: UserAgent}
 	c.Product = &ProductServiceOp{client: c}
 	c.CustomCollection = &CustomCollectionServiceOp{client: c}
 	c.SmartCollection = &SmartCollectionServiceOp{client: c}
@@ -193,34 +270,75 @@ func NewClient(app App, shopName, token string) *Client {
 	c.ApplicationCharge = &ApplicationChargeServiceOp{client: c}
 	c.Redirect = &RedirectServiceOp{client: c}
 	c.Page = &PageServiceOp{client: c}
+	c.GraphQL = &GraphQLServiceOp{client: c}
+	c.BulkOperation = &BulkOperationServiceOp{client: c}
+	c.AccessScope = &AccessScopeServiceOp{client: c}
 
 	return c
 }
 
 // Do sends an API request and populates the given interface with the parsed
 // response. It does not make much sense to call Do without a prepared
-// interface instance.
+// interface instance. The request's context is used for cancellation, see
+// NewRequestWithContext. Failed requests are retried according to the
+// client's RetryPolicy, if any.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.Client.Do(req)
+	_, err := c.doGetHeaders(req, v)
+	return err
+}
+
+// doGetHeaders is like Do but also returns the response headers.
+func (c *Client) doGetHeaders(req *http.Request, v interface{}) (http.Header, error) {
+	resp, err := c.doWithRetry(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	err = CheckResponseError(resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if v != nil {
 		decoder := json.NewDecoder(resp.Body)
 		err := decoder.Decode(&v)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return resp.Header, nil
+}
+
+// send performs a single request, waiting for the rate limiter first and
+// recording the call limit and API version reported in the response. GraphQL
+// requests don't wait for the rate limiter, as they have a bucket of their
+// own.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.RateLimiter != nil && !isGraphQLRequest(req) {
+		if err := c.RateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit, ok := parseCallLimit(resp); ok {
+		c.mu.Lock()
+		c.callLimit = limit
+		c.mu.Unlock()
+
+		if c.RateLimiter != nil {
+			c.RateLimiter.Update(limit)
+		}
+	}
+	c.checkAPIVersion(req, resp)
+
+	return resp, nil
 }
 
 func wrapSpecificError(r *http.Response, err ResponseError) error {
@@ -325,7 +443,9 @@ func CheckResponseError(r *http.Response) error {
 }
 
 // General list options that can be used for most collections of entities.
+// PageInfo is the cursor for cursor-based pagination, see Pagination.
 type ListOptions struct {
+	PageInfo     string    `url:"page_info,omitempty"`
 	Page         int       `url:"page,omitempty"`
 	Limit        int       `url:"limit,omitempty"`
 	SinceID      int       `url:"since_id,omitempty"`
@@ -345,11 +465,18 @@ type CountOptions struct {
 	UpdatedAtMax time.Time `url:"updated_at_max,omitempty"`
 }
 
+// Count performs a GET request for the given count path and returns the
+// count from the response.
 func (c *Client) Count(path string, options interface{}) (int, error) {
+	return c.CountWithContext(context.Background(), path, options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (c *Client) CountWithContext(ctx context.Context, path string, options interface{}) (int, error) {
 	resource := struct {
 		Count int `json:"count"`
 	}{}
-	err := c.Get(path, &resource, options)
+	err := c.GetWithContext(ctx, path, &resource, options)
 	return resource.Count, err
 }
 
@@ -363,7 +490,13 @@ func (c *Client) Count(path string, options interface{}) (int, error) {
 // parameters like created_at_min
 // Any data returned from Shopify will be marshalled into resource argument.
 func (c *Client) CreateAndDo(method, path string, data, options, resource interface{}) error {
-	req, err := c.NewRequest(method, path, data, options)
+	return c.CreateAndDoWithContext(context.Background(), method, path, data, options, resource)
+}
+
+// CreateAndDoWithContext is like CreateAndDo but uses ctx for the request.
+// The request is aborted when ctx is cancelled or its deadline is exceeded.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, path string, data, options, resource interface{}) error {
+	req, err := c.NewRequestWithContext(ctx, method, path, data, options)
 	if err != nil {
 		return err
 	}
@@ -379,22 +512,42 @@ func (c *Client) CreateAndDo(method, path string, data, options, resource interf
 // Get performs a GET request for the given path and saves the result in the
 // given resource.
 func (c *Client) Get(path string, resource, options interface{}) error {
-	return c.CreateAndDo("GET", path, nil, options, resource)
+	return c.GetWithContext(context.Background(), path, resource, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (c *Client) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, resource)
 }
 
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
-	return c.CreateAndDo("POST", path, data, nil, resource)
+	return c.PostWithContext(context.Background(), path, data, resource)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (c *Client) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, resource)
 }
 
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *Client) Put(path string, data, resource interface{}) error {
-	return c.CreateAndDo("PUT", path, data, nil, resource)
+	return c.PutWithContext(context.Background(), path, data, resource)
+}
+
+// PutWithContext is like Put but uses ctx for the request.
+func (c *Client) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, resource)
 }
 
 // Delete performs a DELETE request for the given path
 func (c *Client) Delete(path string) error {
-	return c.CreateAndDo("DELETE", path, nil, nil, nil)
+	return c.DeleteWithContext(context.Background(), path)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (c *Client) DeleteWithContext(ctx context.Context, path string) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil)
 }
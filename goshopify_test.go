@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -10,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -184,6 +186,22 @@ func TestNewRequestMissingToken(t *testing.T) {
 	}
 }
 
+func TestNewRequestWithContext(t *testing.T) {
+	testClient := NewClient(app, "fooshop", "abcd")
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "foo")
+
+	req, err := testClient.NewRequestWithContext(ctx, "GET", "foo", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext() err = %v, expected nil", err)
+	}
+
+	if req.Context().Value(ctxKey{}) != "foo" {
+		t.Errorf("NewRequestWithContext() did not attach the given context")
+	}
+}
+
 func TestNewRequestError(t *testing.T) {
 	testClient := NewClient(app, "fooshop", "abcd")
 
@@ -429,6 +447,32 @@ func TestCreateAndDo(t *testing.T) {
 	}
 }
 
+func TestCreateAndDoWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo/1",
+		func(req *http.Request) (*http.Response, error) {
+			if err := req.Context().Err(); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(200, `{"foo": "bar"}`), nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.CreateAndDoWithContext(ctx, "GET", "foo/1", nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Errorf("CreateAndDoWithContext(): expected %v, actual %v", context.Canceled, err)
+	}
+
+	err = client.CreateAndDoWithContext(context.Background(), "GET", "foo/1", nil, nil, nil)
+	if err != nil {
+		t.Errorf("CreateAndDoWithContext(): expected nil error, actual %v", err)
+	}
+}
+
 func TestResponseErrorError(t *testing.T) {
 	cases := []struct {
 		err      ResponseError
@@ -548,3 +592,28 @@ func TestCount(t *testing.T) {
 		t.Errorf("Client.Count returned %d, expected %d", cnt, expected)
 	}
 }
+
+func TestNewClientInvalidShop(t *testing.T) {
+	for _, shopName := range []string{"", "evil.com/x", "fooshop.myshopify.com.evil.com", "foo shop"} {
+		testClient, err := NewClientWithOptions(app, shopName, "abcd")
+		if testClient != nil || err != (ShopDomainError{shopName}) {
+			t.Errorf("NewClientWithOptions(%q) = %v, %v, expected a ShopDomainError", shopName, testClient, err)
+		}
+	}
+
+	// NewClient doesn't validate the shop, as before
+	testClient := NewClient(app, "evil.com/x", "abcd")
+	if testClient.baseURL.String() != "https://evil.com/x.myshopify.com" {
+		t.Errorf("NewClient() base url = %v, expected https://evil.com/x.myshopify.com", testClient.baseURL)
+	}
+
+	devApp := App{ShopDomainSuffixes: []string{"myshopify.io"}}
+	testClient, err := NewClientWithOptions(devApp, "fooshop.myshopify.io", "abcd")
+	if err != nil || testClient.baseURL.String() != "https://fooshop.myshopify.io" {
+		t.Errorf("NewClientWithOptions() = %v, %v, expected a client for the development store", testClient, err)
+	}
+	testClient = NewClient(devApp, "fooshop.myshopify.io", "abcd")
+	if testClient.baseURL.String() != "https://fooshop.myshopify.io" {
+		t.Errorf("NewClient() base url = %v, expected https://fooshop.myshopify.io", testClient.baseURL)
+	}
+}
@@ -0,0 +1,230 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// GraphQLService is an interface for interfacing with the GraphQL Admin API.
+// See: https://help.shopify.com/api/graphql-admin-api
+type GraphQLService interface {
+	Query(string, interface{}, interface{}) (*GraphQLCost, error)
+
+	QueryWithContext(context.Context, string, interface{}, interface{}) (*GraphQLCost, error)
+}
+
+// GraphQLServiceOp handles communication with the GraphQL Admin API.
+type GraphQLServiceOp struct {
+	client *Client
+}
+
+// GraphQLCost is the cost of a GraphQL query, as reported in the
+// extensions.cost field of the response.
+type GraphQLCost struct {
+	RequestedQueryCost int                   `json:"requestedQueryCost"`
+	ActualQueryCost    *int                  `json:"actualQueryCost"`
+	ThrottleStatus     GraphQLThrottleStatus `json:"throttleStatus"`
+}
+
+// GraphQLThrottleStatus is the state of the shop's GraphQL cost bucket.
+type GraphQLThrottleStatus struct {
+	MaximumAvailable   float64 `json:"maximumAvailable"`
+	CurrentlyAvailable float64 `json:"currentlyAvailable"`
+	RestoreRate        float64 `json:"restoreRate"`
+}
+
+// GraphQLError is an entry of the top-level errors of a GraphQL response.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Locations  []GraphQLErrorLocation `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLErrorLocation is the position of an error in a GraphQL document.
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Code returns the error code from the extensions of the error, e.g.
+// "THROTTLED", if there is one.
+func (e GraphQLError) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// GraphQLUserError is an entry of the userErrors field of a mutation payload.
+type GraphQLUserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+}
+
+// GraphQLResponseError occurs when a GraphQL response has top-level errors,
+// e.g. for an invalid query. Embeds the ResponseError to allow consumers to
+// handle it the same way as a normal ResponseError.
+type GraphQLResponseError struct {
+	ResponseError
+	GraphQLErrors []GraphQLError
+}
+
+// GraphQLUserErrors occurs when a mutation payload has userErrors, e.g. for
+// invalid input. The data is still decoded. Embeds the ResponseError to allow
+// consumers to handle it the same way as a normal ResponseError.
+type GraphQLUserErrors struct {
+	ResponseError
+	UserErrors []GraphQLUserError
+}
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Query     string      `json:"query"`
+	Variables interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the body of a GraphQL response.
+type graphQLResponse struct {
+	Data       json.RawMessage `json:"data"`
+	Errors     []GraphQLError  `json:"errors"`
+	Extensions struct {
+		Cost *GraphQLCost `json:"cost"`
+	} `json:"extensions"`
+}
+
+// graphQLPath returns the path of the GraphQL endpoint for the client's API
+// version.
+func (s *GraphQLServiceOp) graphQLPath() string {
+	if s.client.apiVersion != "" {
+		return fmt.Sprintf("admin/api/%s/graphql.json", s.client.apiVersion)
+	}
+	return "admin/api/graphql.json"
+}
+
+// Query posts a GraphQL query or mutation with the given variables, which may
+// be nil, and decodes the data of the response into data. Top-level errors
+// are returned as a GraphQLResponseError and userErrors as GraphQLUserErrors.
+// The cost of the query is returned whenever Shopify reports it, also when the
+// query failed. If the client has a GraphQLLimiter, the query waits for room
+// in the shop's cost bucket and throttled queries are retried.
+func (s *GraphQLServiceOp) Query(query string, variables, data interface{}) (*GraphQLCost, error) {
+	return s.QueryWithContext(context.Background(), query, variables, data)
+}
+
+// QueryWithContext is like Query but uses ctx for the request.
+func (s *GraphQLServiceOp) QueryWithContext(ctx context.Context, query string, variables, data interface{}) (*GraphQLCost, error) {
+	limiter := s.client.GraphQLLimiter
+	if limiter == nil {
+		return s.query(ctx, query, variables, data)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := limiter.Wait(ctx, limiter.queryCost(query)); err != nil {
+			return nil, err
+		}
+
+		cost, err := s.query(ctx, query, variables, data)
+		if cost != nil {
+			limiter.Update(cost.ThrottleStatus)
+			limiter.setQueryCost(query, cost.RequestedQueryCost)
+		}
+		if !isThrottled(err) || attempt >= limiter.MaxRetries {
+			return cost, err
+		}
+		s.client.logf("goshopify: retrying throttled graphql query (attempt %d of %d)", attempt+2, limiter.MaxRetries+1)
+	}
+}
+
+// query runs a GraphQL query once.
+func (s *GraphQLServiceOp) query(ctx context.Context, query string, variables, data interface{}) (*GraphQLCost, error) {
+	// GraphQL queries are limited by cost, see GraphQLLimiter, and don't
+	// count against the REST bucket of the RateLimiter
+	ctx = context.WithValue(ctx, graphQLRequestKey{}, true)
+
+	resource := new(graphQLResponse)
+	err := s.client.PostWithContext(ctx, s.graphQLPath(), graphQLRequest{Query: query, Variables: variables}, resource)
+	if err != nil {
+		return nil, err
+	}
+	cost := resource.Extensions.Cost
+
+	// Decode the data first since a response with errors may still have
+	// partial data.
+	if data != nil && len(resource.Data) > 0 {
+		if err := json.Unmarshal(resource.Data, data); err != nil {
+			return cost, ResponseDecodingError{Body: resource.Data, Message: err.Error(), Status: 200}
+		}
+	}
+
+	if len(resource.Errors) > 0 {
+		return cost, newGraphQLResponseError(resource.Errors)
+	}
+
+	if userErrors := findUserErrors(resource.Data); len(userErrors) > 0 {
+		return cost, newGraphQLUserErrors(userErrors)
+	}
+
+	return cost, nil
+}
+
+// graphQLRequestKey marks the context of GraphQL requests.
+type graphQLRequestKey struct{}
+
+// isGraphQLRequest reports whether a request was sent by the GraphQL service.
+func isGraphQLRequest(req *http.Request) bool {
+	graphQL, _ := req.Context().Value(graphQLRequestKey{}).(bool)
+	return graphQL
+}
+
+func newGraphQLResponseError(errs []GraphQLError) GraphQLResponseError {
+	responseError := ResponseError{Status: 200}
+	for _, e := range errs {
+		responseError.Errors = append(responseError.Errors, e.Message)
+	}
+	responseError.Message = strings.Join(responseError.Errors, ", ")
+	return GraphQLResponseError{ResponseError: responseError, GraphQLErrors: errs}
+}
+
+func newGraphQLUserErrors(userErrors []GraphQLUserError) GraphQLUserErrors {
+	responseError := ResponseError{Status: 200}
+	for _, e := range userErrors {
+		msg := e.Message
+		if len(e.Field) > 0 {
+			msg = fmt.Sprintf("%s: %s", strings.Join(e.Field, "."), e.Message)
+		}
+		responseError.Errors = append(responseError.Errors, msg)
+	}
+	responseError.Message = strings.Join(responseError.Errors, ", ")
+	return GraphQLUserErrors{ResponseError: responseError, UserErrors: userErrors}
+}
+
+// findUserErrors collects the userErrors of all the mutation payloads in the
+// data of a response.
+func findUserErrors(data json.RawMessage) []GraphQLUserError {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	// Sort the fields so that the errors come in a stable order
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var userErrors []GraphQLUserError
+	for _, k := range keys {
+		payload := struct {
+			UserErrors []GraphQLUserError `json:"userErrors"`
+		}{}
+		// Fields that aren't payload objects have no user errors
+		if err := json.Unmarshal(fields[k], &payload); err == nil {
+			userErrors = append(userErrors, payload.UserErrors...)
+		}
+	}
+	return userErrors
+}
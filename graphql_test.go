@@ -0,0 +1,157 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestGraphQLQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, _ := NewClientWithOptions(app, "fooshop", "abcd", WithVersion("2019-04"))
+
+	var body map[string]interface{}
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/2019-04/graphql.json",
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			json.Unmarshal(b, &body)
+			return httpmock.NewStringResponse(200, `{
+				"data": {"shop": {"name": "Foo Shop"}},
+				"extensions": {"cost": {
+					"requestedQueryCost": 1,
+					"actualQueryCost": 1,
+					"throttleStatus": {"maximumAvailable": 1000.0, "currentlyAvailable": 999, "restoreRate": 50.0}
+				}}
+			}`), nil
+		})
+
+	data := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	cost, err := c.GraphQL.Query(`query($first: Int) { shop { name } }`, map[string]interface{}{"first": 1}, &data)
+	if err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if data.Shop.Name != "Foo Shop" {
+		t.Errorf("GraphQL.Query data = %+v, expected shop name Foo Shop", data)
+	}
+
+	expectedBody := map[string]interface{}{
+		"query":     `query($first: Int) { shop { name } }`,
+		"variables": map[string]interface{}{"first": 1.0},
+	}
+	if !reflect.DeepEqual(body, expectedBody) {
+		t.Errorf("GraphQL.Query posted %+v, expected %+v", body, expectedBody)
+	}
+
+	actual := 1
+	expectedCost := &GraphQLCost{
+		RequestedQueryCost: 1,
+		ActualQueryCost:    &actual,
+		ThrottleStatus: GraphQLThrottleStatus{
+			MaximumAvailable:   1000,
+			CurrentlyAvailable: 999,
+			RestoreRate:        50,
+		},
+	}
+	if !reflect.DeepEqual(cost, expectedCost) {
+		t.Errorf("GraphQL.Query cost = %+v, expected %+v", cost, expectedCost)
+	}
+}
+
+func TestGraphQLQueryUnversioned(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		httpmock.NewStringResponder(200, `{"data": {"shop": {"name": "Foo Shop"}}}`))
+
+	cost, err := client.GraphQL.Query(`{ shop { name } }`, nil, nil)
+	if err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+	if cost != nil {
+		t.Errorf("GraphQL.Query cost = %+v, expected nil", cost)
+	}
+}
+
+func TestGraphQLQueryErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cases := []struct {
+		body     string
+		expected error
+	}{
+		{
+			`{"errors": [{"message": "Field 'foo' doesn't exist on type 'Shop'", "locations": [{"line": 1, "column": 9}], "path": ["query", "shop", "foo"]}]}`,
+			GraphQLResponseError{
+				ResponseError: ResponseError{
+					Status:  200,
+					Message: "Field 'foo' doesn't exist on type 'Shop'",
+					Errors:  []string{"Field 'foo' doesn't exist on type 'Shop'"},
+				},
+				GraphQLErrors: []GraphQLError{{
+					Message:   "Field 'foo' doesn't exist on type 'Shop'",
+					Locations: []GraphQLErrorLocation{{Line: 1, Column: 9}},
+					Path:      []interface{}{"query", "shop", "foo"},
+				}},
+			},
+		},
+		{
+			`{"data": {"productCreate": {"product": null, "userErrors": [{"field": ["title"], "message": "Title can't be blank"}]}}}`,
+			GraphQLUserErrors{
+				ResponseError: ResponseError{
+					Status:  200,
+					Message: "title: Title can't be blank",
+					Errors:  []string{"title: Title can't be blank"},
+				},
+				UserErrors: []GraphQLUserError{{Field: []string{"title"}, Message: "Title can't be blank"}},
+			},
+		},
+		{
+			`{"data": {"productCreate": {"product": {"id": "gid://shopify/Product/1"}, "userErrors": []}}}`,
+			nil,
+		},
+		{
+			`{"errors": "[API] Invalid API key or access token (unrecognized login or wrong password)"}`,
+			ResponseError{
+				Status:  401,
+				Message: "[API] Invalid API key or access token (unrecognized login or wrong password)",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		status := 200
+		if _, ok := c.expected.(ResponseError); ok {
+			status = 401
+		}
+		httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+			httpmock.NewStringResponder(status, c.body))
+
+		_, err := client.GraphQL.Query(`mutation { productCreate(input: {}) { userErrors { field message } } }`, nil, nil)
+		if !reflect.DeepEqual(err, c.expected) {
+			t.Errorf("GraphQL.Query err = %#v, expected %#v", err, c.expected)
+		}
+	}
+}
+
+func TestGraphQLErrorCode(t *testing.T) {
+	e := GraphQLError{Message: "Throttled", Extensions: map[string]interface{}{"code": "THROTTLED"}}
+	if e.Code() != "THROTTLED" {
+		t.Errorf("GraphQLError.Code() = %v, expected THROTTLED", e.Code())
+	}
+	if (GraphQLError{}).Code() != "" {
+		t.Errorf("GraphQLError.Code() = %v, expected empty", (GraphQLError{}).Code())
+	}
+}
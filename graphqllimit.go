@@ -0,0 +1,186 @@
+package goshopify
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultGraphQLMaxRetries is the number of times a throttled GraphQL query
+// is retried by default.
+const DefaultGraphQLMaxRetries = 5
+
+// DefaultGraphQLCostCacheSize is the number of query costs a GraphQLLimiter
+// remembers by default.
+const DefaultGraphQLCostCacheSize = 1000
+
+// GraphQLLimiter is a client-side model of a shop's GraphQL cost bucket. It is
+// updated from the throttle status of every response and, when set on a
+// Client, makes GraphQL queries wait until the bucket has room for their cost
+// and retries throttled queries.
+//
+// Shopify only reports the cost of a query after running it, so the limiter
+// remembers the requested cost of recent query documents and waits for that
+// much before running them again. Only the CostCacheSize most recently run
+// queries are remembered, so that queries built with inline IDs or cursors
+// don't grow it without limit.
+//
+// A GraphQLLimiter is safe for concurrent use and should be shared by every
+// Client talking to the same shop, since the bucket is per shop.
+type GraphQLLimiter struct {
+	// MaxRetries is the number of times a throttled query is retried.
+	MaxRetries int
+
+	// CostCacheSize is the number of query costs remembered, or
+	// DefaultGraphQLCostCacheSize if it is 0.
+	CostCacheSize int
+
+	mu         sync.Mutex
+	status     GraphQLThrottleStatus
+	updated    time.Time
+	costs      map[string]*list.Element
+	costsOrder *list.List
+}
+
+type graphQLQueryCost struct {
+	query string
+	cost  int
+}
+
+// NewGraphQLLimiter returns a GraphQLLimiter that retries throttled queries
+// DefaultGraphQLMaxRetries times. The size of the bucket is learnt from the
+// first response.
+func NewGraphQLLimiter() *GraphQLLimiter {
+	return &GraphQLLimiter{MaxRetries: DefaultGraphQLMaxRetries}
+}
+
+// available returns the estimated available cost at the given time. The
+// caller must hold the lock.
+func (l *GraphQLLimiter) available(now time.Time) float64 {
+	available := l.status.CurrentlyAvailable + now.Sub(l.updated).Seconds()*l.status.RestoreRate
+	if available > l.status.MaximumAvailable {
+		return l.status.MaximumAvailable
+	}
+	return available
+}
+
+// Status returns the estimated current state of the bucket.
+func (l *GraphQLLimiter) Status() GraphQLThrottleStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	status := l.status
+	if !l.updated.IsZero() {
+		status.CurrentlyAvailable = l.available(time.Now())
+	}
+	return status
+}
+
+// Update sets the state of the bucket as reported by Shopify. The reported
+// amount doesn't include the cost reserved by Wait for queries that are still
+// in flight, so the available amount is never raised above the local
+// estimate.
+func (l *GraphQLLimiter) Update(status GraphQLThrottleStatus) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if !l.updated.IsZero() {
+		if available := l.available(now); available < status.CurrentlyAvailable {
+			status.CurrentlyAvailable = available
+		}
+	}
+	l.status = status
+	l.updated = now
+}
+
+// Wait blocks until the bucket has room for the given cost and reserves it,
+// or until the context is done. It doesn't wait before the state of the
+// bucket is known or for costs larger than the bucket, which Shopify rejects
+// anyway.
+func (l *GraphQLLimiter) Wait(ctx context.Context, cost int) error {
+	for {
+		l.mu.Lock()
+		if l.updated.IsZero() || float64(cost) > l.status.MaximumAvailable {
+			l.mu.Unlock()
+			return nil
+		}
+		now := time.Now()
+		available := l.available(now)
+		if available >= float64(cost) || l.status.RestoreRate <= 0 {
+			l.status.CurrentlyAvailable = available - float64(cost)
+			l.updated = now
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((float64(cost) - available) / l.status.RestoreRate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// queryCost returns the requested cost of the last run of a query, or 0 if
+// it hasn't run yet.
+func (l *GraphQLLimiter) queryCost(query string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.costs[query]
+	if !ok {
+		return 0
+	}
+	l.costsOrder.MoveToFront(e)
+	return e.Value.(*graphQLQueryCost).cost
+}
+
+// setQueryCost remembers the requested cost of a query, evicting the least
+// recently used costs when the cache is full.
+func (l *GraphQLLimiter) setQueryCost(query string, cost int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.costs == nil {
+		l.costs = map[string]*list.Element{}
+		l.costsOrder = list.New()
+	}
+	if e, ok := l.costs[query]; ok {
+		e.Value.(*graphQLQueryCost).cost = cost
+		l.costsOrder.MoveToFront(e)
+		return
+	}
+
+	l.costs[query] = l.costsOrder.PushFront(&graphQLQueryCost{query: query, cost: cost})
+	size := l.CostCacheSize
+	if size <= 0 {
+		size = DefaultGraphQLCostCacheSize
+	}
+	for l.costsOrder.Len() > size {
+		oldest := l.costsOrder.Back()
+		l.costsOrder.Remove(oldest)
+		delete(l.costs, oldest.Value.(*graphQLQueryCost).query)
+	}
+}
+
+// WithGraphQLLimiter makes the client wait for the given limiter before
+// running GraphQL queries and retry throttled queries. See GraphQLLimiter.
+func WithGraphQLLimiter(limiter *GraphQLLimiter) Option {
+	return func(c *Client) error {
+		c.GraphQLLimiter = limiter
+		return nil
+	}
+}
+
+// isThrottled reports whether an error from a GraphQL query is because the
+// shop's cost bucket was empty.
+func isThrottled(err error) bool {
+	responseErr, ok := err.(GraphQLResponseError)
+	if !ok {
+		return false
+	}
+	for _, e := range responseErr.GraphQLErrors {
+		if e.Code() == "THROTTLED" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+// graphQLCostResponder responds with the given data or errors and a cost
+// extension with the given availability.
+func graphQLCostResponder(result string, requested int, available float64) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, fmt.Sprintf(`{%s, "extensions": {"cost": {
+			"requestedQueryCost": %d,
+			"throttleStatus": {"maximumAvailable": 100.0, "currentlyAvailable": %f, "restoreRate": 1000.0}
+		}}}`, result, requested, available)), nil
+	}
+}
+
+const throttledResult = `"errors": [{"message": "Throttled", "extensions": {"code": "THROTTLED", "documentation": "https://help.shopify.com/api/usage/rate-limits"}}]`
+
+func TestGraphQLQueryThrottledRetry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := NewGraphQLLimiter()
+	c, _ := NewClientWithOptions(app, "fooshop", "abcd", WithGraphQLLimiter(limiter))
+
+	calls := 0
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		sequenceResponder(&calls,
+			graphQLCostResponder(throttledResult, 10, 5),
+			graphQLCostResponder(`"data": {"shop": {"name": "Foo Shop"}}`, 10, 90),
+		))
+
+	data := struct {
+		Shop struct {
+			Name string `json:"name"`
+		} `json:"shop"`
+	}{}
+	cost, err := c.GraphQL.Query(`{ shop { name } }`, nil, &data)
+	if err != nil {
+		t.Fatalf("GraphQL.Query returned error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("GraphQL.Query made %d calls, expected 2", calls)
+	}
+	if data.Shop.Name != "Foo Shop" {
+		t.Errorf("GraphQL.Query data = %+v, expected shop name Foo Shop", data)
+	}
+	if cost.ThrottleStatus.CurrentlyAvailable != 90 {
+		t.Errorf("GraphQL.Query cost = %+v, expected 90 available", cost)
+	}
+	if limiter.queryCost(`{ shop { name } }`) != 10 {
+		t.Errorf("GraphQLLimiter did not remember the query cost")
+	}
+}
+
+func TestGraphQLQueryThrottledMaxRetries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := NewGraphQLLimiter()
+	limiter.MaxRetries = 2
+	c, _ := NewClientWithOptions(app, "fooshop", "abcd", WithGraphQLLimiter(limiter))
+
+	calls := 0
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		sequenceResponder(&calls, graphQLCostResponder(throttledResult, 10, 9.9)))
+
+	_, err := c.GraphQL.Query(`{ shop { name } }`, nil, nil)
+	if !isThrottled(err) {
+		t.Errorf("GraphQL.Query err = %v, expected a throttled error", err)
+	}
+	if calls != 3 {
+		t.Errorf("GraphQL.Query made %d calls, expected 3", calls)
+	}
+}
+
+func TestGraphQLQueryNotRetriedWithoutLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		sequenceResponder(&calls, graphQLCostResponder(throttledResult, 10, 5)))
+
+	_, err := client.GraphQL.Query(`{ shop { name } }`, nil, nil)
+	if !isThrottled(err) {
+		t.Errorf("GraphQL.Query err = %v, expected a throttled error", err)
+	}
+	if calls != 1 {
+		t.Errorf("GraphQL.Query made %d calls, expected 1", calls)
+	}
+}
+
+func TestGraphQLLimiterWait(t *testing.T) {
+	limiter := NewGraphQLLimiter()
+
+	// Nothing is known about the bucket yet
+	if err := limiter.Wait(context.Background(), 50); err != nil {
+		t.Errorf("GraphQLLimiter.Wait() returned error: %v", err)
+	}
+
+	limiter.Update(GraphQLThrottleStatus{MaximumAvailable: 100, CurrentlyAvailable: 60, RestoreRate: 1})
+
+	// There's room for a query, which is reserved
+	if err := limiter.Wait(context.Background(), 50); err != nil {
+		t.Errorf("GraphQLLimiter.Wait() returned error: %v", err)
+	}
+	if status := limiter.Status(); status.CurrentlyAvailable < 10 || status.CurrentlyAvailable > 11 {
+		t.Errorf("GraphQLLimiter.Status() = %+v, expected about 10 available", status)
+	}
+
+	// The next one has to wait 40 seconds
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, 50); err != context.DeadlineExceeded {
+		t.Errorf("GraphQLLimiter.Wait() returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	// Queries larger than the bucket are not held back
+	if err := limiter.Wait(context.Background(), 200); err != nil {
+		t.Errorf("GraphQLLimiter.Wait() returned error: %v", err)
+	}
+}
+
+func TestGraphQLLimiterUpdateKeepsReservations(t *testing.T) {
+	limiter := NewGraphQLLimiter()
+	limiter.Update(GraphQLThrottleStatus{MaximumAvailable: 1000, CurrentlyAvailable: 1000, RestoreRate: 50})
+
+	for i := 0; i < 9; i++ {
+		if err := limiter.Wait(context.Background(), 100); err != nil {
+			t.Fatalf("GraphQLLimiter.Wait() returned error: %v", err)
+		}
+	}
+
+	// The response to the first query doesn't know about the 8 in flight
+	limiter.Update(GraphQLThrottleStatus{MaximumAvailable: 1000, CurrentlyAvailable: 900, RestoreRate: 50})
+	if status := limiter.Status(); status.CurrentlyAvailable < 100 || status.CurrentlyAvailable > 101 {
+		t.Errorf("GraphQLLimiter.Status() = %+v, expected about 100 available", status)
+	}
+
+	// A throttled response lowers the estimate
+	limiter.Update(GraphQLThrottleStatus{MaximumAvailable: 1000, CurrentlyAvailable: 20, RestoreRate: 50})
+	if status := limiter.Status(); status.CurrentlyAvailable < 20 || status.CurrentlyAvailable > 21 {
+		t.Errorf("GraphQLLimiter.Status() = %+v, expected about 20 available", status)
+	}
+}
+
+func TestGraphQLLimiterStatusRestores(t *testing.T) {
+	limiter := NewGraphQLLimiter()
+	limiter.Update(GraphQLThrottleStatus{MaximumAvailable: 100, CurrentlyAvailable: 99, RestoreRate: 1000})
+
+	time.Sleep(5 * time.Millisecond)
+
+	expected := GraphQLThrottleStatus{MaximumAvailable: 100, CurrentlyAvailable: 100, RestoreRate: 1000}
+	if status := limiter.Status(); status != expected {
+		t.Errorf("GraphQLLimiter.Status() = %+v, expected %+v", status, expected)
+	}
+}
+
+func TestGraphQLLimiterCostCacheEviction(t *testing.T) {
+	limiter := NewGraphQLLimiter()
+	limiter.CostCacheSize = 2
+
+	limiter.setQueryCost("query a", 10)
+	limiter.setQueryCost("query b", 20)
+
+	// Using a makes b the least recently used
+	if cost := limiter.queryCost("query a"); cost != 10 {
+		t.Errorf("GraphQLLimiter.queryCost(a) = %d, expected 10", cost)
+	}
+	limiter.setQueryCost("query c", 30)
+
+	expected := map[string]int{"query a": 10, "query b": 0, "query c": 30}
+	for query, cost := range expected {
+		if actual := limiter.queryCost(query); actual != cost {
+			t.Errorf("GraphQLLimiter.queryCost(%q) = %d, expected %d", query, actual, cost)
+		}
+	}
+	if len(limiter.costs) != 2 {
+		t.Errorf("GraphQLLimiter remembers %d costs, expected 2", len(limiter.costs))
+	}
+}
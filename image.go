@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -15,6 +16,13 @@ type ImageService interface {
 	Create(int, Image) (*Image, error)
 	Update(int, Image) (*Image, error)
 	Delete(int, int) error
+
+	ListWithContext(context.Context, int, interface{}) ([]Image, error)
+	CountWithContext(context.Context, int, interface{}) (int, error)
+	GetWithContext(context.Context, int, int, interface{}) (*Image, error)
+	CreateWithContext(context.Context, int, Image) (*Image, error)
+	UpdateWithContext(context.Context, int, Image) (*Image, error)
+	DeleteWithContext(context.Context, int, int) error
 }
 
 // ImageServiceOp handles communication with the image related methods of
@@ -50,23 +58,38 @@ type ImagesResource struct {
 
 // List images
 func (s *ImageServiceOp) List(productID int, options interface{}) ([]Image, error) {
+	return s.ListWithContext(context.Background(), productID, options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *ImageServiceOp) ListWithContext(ctx context.Context, productID int, options interface{}) ([]Image, error) {
 	path := fmt.Sprintf("%s/%d/images.json", productsBasePath, productID)
 	resource := new(ImagesResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Images, err
 }
 
 // Count images
 func (s *ImageServiceOp) Count(productID int, options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), productID, options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *ImageServiceOp) CountWithContext(ctx context.Context, productID int, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/%d/images/count.json", productsBasePath, productID)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual image
 func (s *ImageServiceOp) Get(productID int, imageID int, options interface{}) (*Image, error) {
+	return s.GetWithContext(context.Background(), productID, imageID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ImageServiceOp) GetWithContext(ctx context.Context, productID int, imageID int, options interface{}) (*Image, error) {
 	path := fmt.Sprintf("%s/%d/images/%d.json", productsBasePath, productID, imageID)
 	resource := new(ImageResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Image, err
 }
 
@@ -84,23 +107,38 @@ func (s *ImageServiceOp) Get(productID int, imageID int, options interface{}) (*
 //
 // Shopify will accept Image.Attachment without Image.Filename.
 func (s *ImageServiceOp) Create(productID int, image Image) (*Image, error) {
+	return s.CreateWithContext(context.Background(), productID, image)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *ImageServiceOp) CreateWithContext(ctx context.Context, productID int, image Image) (*Image, error) {
 	path := fmt.Sprintf("%s/%d/images.json", productsBasePath, productID)
 	wrappedData := ImageResource{Image: &image}
 	resource := new(ImageResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Image, err
 }
 
 // Update an existing image
 func (s *ImageServiceOp) Update(productID int, image Image) (*Image, error) {
+	return s.UpdateWithContext(context.Background(), productID, image)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *ImageServiceOp) UpdateWithContext(ctx context.Context, productID int, image Image) (*Image, error) {
 	path := fmt.Sprintf("%s/%d/images/%d.json", productsBasePath, productID, image.ID)
 	wrappedData := ImageResource{Image: &image}
 	resource := new(ImageResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Image, err
 }
 
 // Delete an existing image
 func (s *ImageServiceOp) Delete(productID int, imageID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d/images/%d.json", productsBasePath, productID, imageID))
+	return s.DeleteWithContext(context.Background(), productID, imageID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ImageServiceOp) DeleteWithContext(ctx context.Context, productID int, imageID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d/images/%d.json", productsBasePath, productID, imageID))
 }
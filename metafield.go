@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -10,11 +11,23 @@ import (
 // https://help.shopify.com/api/reference/metafield
 type MetafieldService interface {
 	List(interface{}) ([]Metafield, error)
+	ListPage(interface{}) ([]Metafield, *Pagination, error)
+	ListAll(interface{}) ([]Metafield, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Metafield, error)
 	Create(Metafield) (*Metafield, error)
 	Update(Metafield) (*Metafield, error)
 	Delete(int) error
+
+	ListWithContext(context.Context, interface{}) ([]Metafield, error)
+	ListPageWithContext(context.Context, interface{}) ([]Metafield, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Metafield, error)
+	Iterate(context.Context, interface{}) <-chan MetafieldResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Metafield, error)
+	CreateWithContext(context.Context, Metafield) (*Metafield, error)
+	UpdateWithContext(context.Context, Metafield) (*Metafield, error)
+	DeleteWithContext(context.Context, int) error
 }
 
 // MetafieldsService is an interface for other Shopify resources
@@ -27,6 +40,13 @@ type MetafieldsService interface {
 	CreateMetafield(int, Metafield) (*Metafield, error)
 	UpdateMetafield(int, Metafield) (*Metafield, error)
 	DeleteMetafield(int, int) error
+
+	ListMetafieldsWithContext(context.Context, int, interface{}) ([]Metafield, error)
+	CountMetafieldsWithContext(context.Context, int, interface{}) (int, error)
+	GetMetafieldWithContext(context.Context, int, int, interface{}) (*Metafield, error)
+	CreateMetafieldWithContext(context.Context, int, Metafield) (*Metafield, error)
+	UpdateMetafieldWithContext(context.Context, int, Metafield) (*Metafield, error)
+	DeleteMetafieldWithContext(context.Context, int, int) error
 }
 
 // MetafieldServiceOp handles communication with the metafield
@@ -61,53 +81,152 @@ type MetafieldsResource struct {
 	Metafields []Metafield `json:"metafields"`
 }
 
+// MetafieldResult is a metafield or an error from Iterate.
+type MetafieldResult struct {
+	Metafield Metafield
+	Err       error
+}
+
 // List metafields
 func (s *MetafieldServiceOp) List(options interface{}) ([]Metafield, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *MetafieldServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
 	resource := new(MetafieldsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Metafields, err
 }
 
+// ListPage lists a page of metafields along with its pagination
+func (s *MetafieldServiceOp) ListPage(options interface{}) ([]Metafield, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *MetafieldServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Metafield, *Pagination, error) {
+	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s.json", prefix)
+	resource := new(MetafieldsResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Metafields, pagination, err
+}
+
+// ListAll lists all metafields, following the pagination from the given options
+func (s *MetafieldServiceOp) ListAll(options interface{}) ([]Metafield, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *MetafieldServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Metafield, error) {
+	var collector []Metafield
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		metafields, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, metafields...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all metafields over the returned channel, fetching the pages
+// lazily. The channel is closed after the last metafield or after a result
+// with an error. Cancel ctx to stop early.
+func (s *MetafieldServiceOp) Iterate(ctx context.Context, options interface{}) <-chan MetafieldResult {
+	results := make(chan MetafieldResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			metafields, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, metafield := range metafields {
+				select {
+				case results <- MetafieldResult{Metafield: metafield}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- MetafieldResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count metafields
 func (s *MetafieldServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *MetafieldServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/count.json", prefix)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual metafield
 func (s *MetafieldServiceOp) Get(metafieldID int, options interface{}) (*Metafield, error) {
+	return s.GetWithContext(context.Background(), metafieldID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *MetafieldServiceOp) GetWithContext(ctx context.Context, metafieldID int, options interface{}) (*Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, metafieldID)
 	resource := new(MetafieldResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Metafield, err
 }
 
 // Create a new metafield
 func (s *MetafieldServiceOp) Create(metafield Metafield) (*Metafield, error) {
+	return s.CreateWithContext(context.Background(), metafield)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *MetafieldServiceOp) CreateWithContext(ctx context.Context, metafield Metafield) (*Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s.json", prefix)
 	wrappedData := MetafieldResource{Metafield: &metafield}
 	resource := new(MetafieldResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Metafield, err
 }
 
 // Update an existing metafield
 func (s *MetafieldServiceOp) Update(metafield Metafield) (*Metafield, error) {
+	return s.UpdateWithContext(context.Background(), metafield)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *MetafieldServiceOp) UpdateWithContext(ctx context.Context, metafield Metafield) (*Metafield, error) {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/%d.json", prefix, metafield.ID)
 	wrappedData := MetafieldResource{Metafield: &metafield}
 	resource := new(MetafieldResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Metafield, err
 }
 
 // Delete an existing metafield
 func (s *MetafieldServiceOp) Delete(metafieldID int) error {
+	return s.DeleteWithContext(context.Background(), metafieldID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *MetafieldServiceOp) DeleteWithContext(ctx context.Context, metafieldID int) error {
 	prefix := MetafieldPathPrefix(s.resource, s.resourceID)
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", prefix, metafieldID))
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", prefix, metafieldID))
 }
@@ -1,14 +1,13 @@
 package goshopify
 
 import (
-	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/hex"
-	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 const shopifyChecksumHeader = "X-Shopify-Hmac-Sha256"
@@ -16,24 +15,108 @@ const shopifyChecksumHeader = "X-Shopify-Hmac-Sha256"
 // Returns a Shopify oauth authorization url for the given shopname and state.
 //
 // State is a unique value that can be used to check the authenticity during a
-// callback from Shopify.
-func (app App) AuthorizeUrl(shopName string, state string) string {
-	shopUrl, _ := url.Parse(ShopBaseUrl(shopName))
+// callback from Shopify. A ShopDomainError is returned if the shop is not
+// valid, see ValidateShopDomain.
+func (app App) AuthorizeUrl(shopName string, state string) (string, error) {
+	return app.authorizeUrl(shopName, state, false)
+}
+
+// OnlineAuthorizeUrl is like AuthorizeUrl but requests an online access token,
+// which is tied to the staff member that authorizes the app and expires when
+// their session does.
+// See: https://help.shopify.com/api/getting-started/authentication/oauth#api-access-modes
+func (app App) OnlineAuthorizeUrl(shopName string, state string) (string, error) {
+	return app.authorizeUrl(shopName, state, true)
+}
+
+func (app App) authorizeUrl(shopName string, state string, online bool) (string, error) {
+	shopDomain, err := app.ShopDomain(shopName)
+	if err != nil {
+		return "", err
+	}
+
+	shopUrl := &url.URL{Scheme: "https", Host: shopDomain}
 	shopUrl.Path = "/admin/oauth/authorize"
 	query := shopUrl.Query()
 	query.Set("client_id", app.ApiKey)
 	query.Set("redirect_uri", app.RedirectUrl)
 	query.Set("scope", app.Scope)
 	query.Set("state", state)
+	if online {
+		query.Set("grant_options[]", "per-user")
+	}
 	shopUrl.RawQuery = query.Encode()
-	return shopUrl.String()
+	return shopUrl.String(), nil
+}
+
+// AccessToken is the response of Shopify to an access token request.
+type AccessToken struct {
+	Token string `json:"access_token"`
+	Scope string `json:"scope"`
+
+	// ExpiresIn is the number of seconds an online token is valid for. It
+	// is 0 for offline tokens, which don't expire.
+	ExpiresIn int `json:"expires_in,omitempty"`
+
+	// ExpiresAt is when an online token expires, computed from ExpiresIn
+	// when the token is received. It is kept when the token is stored as
+	// JSON, unlike ExpiresIn which is relative to when it was received.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// AssociatedUserScope and AssociatedUser are only set for online
+	// tokens.
+	AssociatedUserScope string          `json:"associated_user_scope,omitempty"`
+	AssociatedUser      *AssociatedUser `json:"associated_user,omitempty"`
+}
+
+// AssociatedUser is the staff member an online access token acts for.
+type AssociatedUser struct {
+	ID            int    `json:"id"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	AccountOwner  bool   `json:"account_owner"`
+	Locale        string `json:"locale"`
+	Collaborator  bool   `json:"collaborator"`
+}
+
+// Online reports whether the token is an online token, i.e. tied to a staff
+// member.
+func (t AccessToken) Online() bool {
+	return t.AssociatedUser != nil
+}
+
+// Expired reports whether an online token has expired. Offline tokens never
+// expire.
+func (t AccessToken) Expired() bool {
+	return !t.ExpiresAt.IsZero() && !time.Now().Before(t.ExpiresAt)
 }
 
 func (app App) GetAccessToken(shopName string, code string) (string, error) {
-	type Token struct {
-		Token string `json:"access_token"`
+	return app.GetAccessTokenWithContext(context.Background(), shopName, code)
+}
+
+// GetAccessTokenWithContext is like GetAccessToken but uses ctx for the
+// request.
+func (app App) GetAccessTokenWithContext(ctx context.Context, shopName string, code string) (string, error) {
+	token, err := app.RequestAccessTokenWithContext(ctx, shopName, code)
+	if err != nil {
+		return "", err
 	}
+	return token.Token, nil
+}
 
+// RequestAccessToken is like GetAccessToken but returns the whole response,
+// including the granted scopes and, for online tokens, their expiry and
+// staff member.
+func (app App) RequestAccessToken(shopName string, code string) (*AccessToken, error) {
+	return app.RequestAccessTokenWithContext(context.Background(), shopName, code)
+}
+
+// RequestAccessTokenWithContext is like RequestAccessToken but uses ctx for
+// the request.
+func (app App) RequestAccessTokenWithContext(ctx context.Context, shopName string, code string) (*AccessToken, error) {
 	data := struct {
 		ClientId     string `json:"client_id"`
 		ClientSecret string `json:"client_secret"`
@@ -43,20 +126,31 @@ func (app App) GetAccessToken(shopName string, code string) (string, error) {
 		ClientSecret: app.ApiSecret,
 		Code:         code,
 	}
+	return app.requestAccessToken(ctx, shopName, data)
+}
 
-	client := NewClient(app, shopName, "")
-	req, err := client.NewRequest("POST", "admin/oauth/access_token", data, nil)
+// requestAccessToken posts a grant to the access token endpoint of the shop.
+func (app App) requestAccessToken(ctx context.Context, shopName string, data interface{}) (*AccessToken, error) {
+	client, err := NewClientWithOptions(app, shopName, "")
+	if err != nil {
+		return nil, err
+	}
+	req, err := client.NewRequestWithContext(ctx, "POST", "admin/oauth/access_token", data, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	token := new(Token)
+	token := new(AccessToken)
 	err = client.Do(req, token)
-	return token.Token, err
+	if token.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token, err
 }
 
 // Verify a message against a message HMAC
 func (app App) VerifyMessage(message, messageMAC string) bool {
-	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
-	mac.Write([]byte(message))
-	expectedMAC := mac.Sum(nil)
+	expectedMAC := app.sign([]byte(message))
 
 	// shopify HMAC is in hex so it needs to be decoded
 	actualMac, _ := hex.DecodeString(messageMAC)
@@ -64,6 +158,13 @@ func (app App) VerifyMessage(message, messageMAC string) bool {
 	return hmac.Equal(actualMac, expectedMAC)
 }
 
+// sign returns the HMAC-SHA256 of a message with the app's secret.
+func (app App) sign(message []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
 // Verifying URL callback parameters.
 func (app App) VerifyAuthorizationURL(u *url.URL) (bool, error) {
 	q := u.Query()
@@ -79,17 +180,10 @@ func (app App) VerifyAuthorizationURL(u *url.URL) (bool, error) {
 }
 
 // Verifies a webhook http request, sent by Shopify.
-// The body of the request is still readable after invoking the method.
+// The body of the request is still readable after invoking the method. Use
+// VerifyWebhook to limit the size of the body and to know why a request
+// doesn't verify.
 func (app App) VerifyWebhookRequest(httpRequest *http.Request) bool {
-	shopifySha256 := httpRequest.Header.Get(shopifyChecksumHeader)
-	actualMac := []byte(shopifySha256)
-
-	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
-	requestBody, _ := ioutil.ReadAll(httpRequest.Body)
-	httpRequest.Body = ioutil.NopCloser(bytes.NewBuffer(requestBody))
-	mac.Write(requestBody)
-	macSum := mac.Sum(nil)
-	expectedMac := []byte(base64.StdEncoding.EncodeToString(macSum))
-
-	return hmac.Equal(actualMac, expectedMac)
+	_, err := app.verifyWebhook(httpRequest, -1, nil)
+	return err == nil
 }
@@ -1,8 +1,11 @@
 package goshopify
 
 import (
+	"encoding/json"
 	"net/url"
+	"reflect"
 	"testing"
+	"time"
 
 	"gopkg.in/jarcoal/httpmock.v1"
 )
@@ -20,7 +23,10 @@ func TestAppAuthorizeUrl(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		actual := app.AuthorizeUrl(c.shopName, c.nonce)
+		actual, err := app.AuthorizeUrl(c.shopName, c.nonce)
+		if err != nil {
+			t.Errorf("App.AuthorizeUrl(): %v", err)
+		}
 		if actual != c.expected {
 			t.Errorf("App.AuthorizeUrl(): expected %s, actual %s", c.expected, actual)
 		}
@@ -92,3 +98,128 @@ func TestVerifyWebhookRequest(t *testing.T) {
 		t.Error("Webhook.verify could not verified message checksum")
 	}
 }
+
+func TestAppOnlineAuthorizeUrl(t *testing.T) {
+	setup()
+	defer teardown()
+
+	expected := "https://fooshop.myshopify.com/admin/oauth/authorize?client_id=apikey&grant_options%5B%5D=per-user&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback&scope=read_products&state=thenonce"
+	actual, err := app.OnlineAuthorizeUrl("fooshop", "thenonce")
+	if err != nil || actual != expected {
+		t.Errorf("App.OnlineAuthorizeUrl(): expected %s, actual %s", expected, actual)
+	}
+}
+
+func TestAppRequestAccessToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(200, `{
+			"access_token": "footoken",
+			"scope": "read_products,read_orders",
+			"expires_in": 86399,
+			"associated_user_scope": "read_products",
+			"associated_user": {
+				"id": 902541635,
+				"first_name": "John",
+				"last_name": "Smith",
+				"email": "john@example.com",
+				"email_verified": true,
+				"account_owner": true,
+				"locale": "en",
+				"collaborator": false
+			}
+		}`))
+
+	before := time.Now()
+	token, err := app.RequestAccessToken("fooshop", "foocode")
+	if err != nil {
+		t.Fatalf("App.RequestAccessToken(): %v", err)
+	}
+
+	expiresAt := token.ExpiresAt
+	token.ExpiresAt = time.Time{}
+	expected := &AccessToken{
+		Token:               "footoken",
+		Scope:               "read_products,read_orders",
+		ExpiresIn:           86399,
+		AssociatedUserScope: "read_products",
+		AssociatedUser: &AssociatedUser{
+			ID:            902541635,
+			FirstName:     "John",
+			LastName:      "Smith",
+			Email:         "john@example.com",
+			EmailVerified: true,
+			AccountOwner:  true,
+			Locale:        "en",
+		},
+	}
+	if !reflect.DeepEqual(token, expected) {
+		t.Errorf("App.RequestAccessToken() returned %+v, expected %+v", token, expected)
+	}
+	if expiresAt.Before(before.Add(86399*time.Second)) || expiresAt.After(time.Now().Add(86399*time.Second)) {
+		t.Errorf("App.RequestAccessToken() returned a token expiring at %v, expected in 86399 seconds", expiresAt)
+	}
+	if !token.Online() {
+		t.Error("AccessToken.Online() returned false for a token with an associated user")
+	}
+}
+
+func TestAccessTokenExpired(t *testing.T) {
+	cases := []struct {
+		token    AccessToken
+		expected bool
+	}{
+		{AccessToken{Token: "offline"}, false},
+		{AccessToken{Token: "online", ExpiresAt: time.Now().Add(time.Hour)}, false},
+		{AccessToken{Token: "online", ExpiresAt: time.Now().Add(-time.Hour)}, true},
+	}
+	for _, c := range cases {
+		if actual := c.token.Expired(); actual != c.expected {
+			t.Errorf("AccessToken{%s}.Expired() returned %v, expected %v", c.token.Token, actual, c.expected)
+		}
+	}
+}
+
+func TestAccessTokenJSON(t *testing.T) {
+	cases := []AccessToken{
+		{Token: "offline", Scope: "read_products"},
+		{Token: "online", ExpiresIn: 3600, ExpiresAt: time.Now().Add(time.Hour).Round(time.Second).UTC()},
+		{Token: "online", ExpiresIn: 3600, ExpiresAt: time.Now().Add(-time.Hour).Round(time.Second).UTC()},
+	}
+	for _, token := range cases {
+		b, err := json.Marshal(token)
+		if err != nil {
+			t.Fatalf("json.Marshal(%+v) returned error: %v", token, err)
+		}
+		actual := AccessToken{}
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Fatalf("json.Unmarshal(%s) returned error: %v", b, err)
+		}
+		if !reflect.DeepEqual(actual, token) {
+			t.Errorf("AccessToken %s decoded to %+v, expected %+v", b, actual, token)
+		}
+		if actual.Expired() != token.Expired() {
+			t.Errorf("AccessToken %s decoded Expired() = %v, expected %v", b, actual.Expired(), token.Expired())
+		}
+	}
+}
+
+func TestAppOAuthInvalidShop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	authUrl, err := app.AuthorizeUrl("evil.com/x", "thenonce")
+	if authUrl != "" || err != (ShopDomainError{"evil.com/x"}) {
+		t.Errorf("App.AuthorizeUrl() = %q, %v, expected a ShopDomainError", authUrl, err)
+	}
+
+	token, err := app.GetAccessToken("fooshop.myshopify.com.evil.com", "foocode")
+	if token != "" || err != (ShopDomainError{"fooshop.myshopify.com.evil.com"}) {
+		t.Errorf("App.GetAccessToken() = %q, %v, expected a ShopDomainError", token, err)
+	}
+	if info := httpmock.GetCallCountInfo(); len(info) != 0 {
+		t.Errorf("App.GetAccessToken() made calls %v, expected none", info)
+	}
+}
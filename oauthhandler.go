@@ -0,0 +1,278 @@
+package goshopify
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultOAuthMaxAge is how old the timestamp of an OAuth request may be
+	// by default.
+	DefaultOAuthMaxAge = 5 * time.Minute
+
+	// DefaultOAuthStateTTL is how long a shop has to complete an
+	// authorization by default.
+	DefaultOAuthStateTTL = 10 * time.Minute
+
+	// DefaultOAuthStateCookie is the name of the cookie of a
+	// CookieOAuthStateStore by default.
+	DefaultOAuthStateCookie = "shopify_oauth_state"
+)
+
+// ErrOAuthStateMismatch is returned by an OAuthStateStore when the state of
+// a callback was not saved for the shop or has expired.
+var ErrOAuthStateMismatch = errors.New("goshopify: oauth state mismatch")
+
+// OAuthStateStore keeps the nonces sent as the state of authorization
+// requests, so that callbacks can be checked to be for an authorization the
+// app started.
+type OAuthStateStore interface {
+	// Save stores the nonce of an authorization of the shop. The response
+	// has not been written yet, so cookies can be set on w.
+	Save(w http.ResponseWriter, r *http.Request, shop, nonce string) error
+
+	// Verify returns ErrOAuthStateMismatch unless the nonce was saved for the
+	// shop, and consumes it so that it can't be used again.
+	Verify(w http.ResponseWriter, r *http.Request, shop, nonce string) error
+}
+
+// CookieOAuthStateStore is an OAuthStateStore that keeps the nonce in a
+// cookie of the browser that is signed with a secret, so nothing has to be
+// stored on the server.
+type CookieOAuthStateStore struct {
+	// Secret signs the cookies.
+	Secret string
+
+	// CookieName is the name of the cookie.
+	CookieName string
+
+	// TTL is how long the nonce is valid.
+	TTL time.Duration
+
+	// Secure sets the Secure flag of the cookie so it is only sent over
+	// HTTPS.
+	Secure bool
+}
+
+// NewCookieOAuthStateStore returns a CookieOAuthStateStore signing secure
+// cookies named DefaultOAuthStateCookie with the secret, valid for
+// DefaultOAuthStateTTL.
+func NewCookieOAuthStateStore(secret string) *CookieOAuthStateStore {
+	return &CookieOAuthStateStore{
+		Secret:     secret,
+		CookieName: DefaultOAuthStateCookie,
+		TTL:        DefaultOAuthStateTTL,
+		Secure:     true,
+	}
+}
+
+// Save sets the cookie with the signed nonce, shop and expiry.
+func (s *CookieOAuthStateStore) Save(w http.ResponseWriter, r *http.Request, shop, nonce string) error {
+	expires := time.Now().Add(s.TTL)
+	value := fmt.Sprintf("%s|%s|%d", nonce, shop, expires.Unix())
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.CookieName,
+		Value:    value + "|" + s.sign(value),
+		Path:     "/",
+		Expires:  expires,
+		MaxAge:   int(s.TTL / time.Second),
+		Secure:   s.Secure,
+		HttpOnly: true,
+	})
+	return nil
+}
+
+// Verify checks the cookie and clears it.
+func (s *CookieOAuthStateStore) Verify(w http.ResponseWriter, r *http.Request, shop, nonce string) error {
+	cookie, err := r.Cookie(s.CookieName)
+	if err != nil {
+		return ErrOAuthStateMismatch
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.CookieName,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   s.Secure,
+		HttpOnly: true,
+	})
+
+	i := strings.LastIndex(cookie.Value, "|")
+	if i < 0 || !hmac.Equal([]byte(cookie.Value[i+1:]), []byte(s.sign(cookie.Value[:i]))) {
+		return ErrOAuthStateMismatch
+	}
+	parts := strings.Split(cookie.Value[:i], "|")
+	if len(parts) != 3 {
+		return ErrOAuthStateMismatch
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return ErrOAuthStateMismatch
+	}
+	if !hmac.Equal([]byte(parts[0]), []byte(nonce)) || parts[1] != shop {
+		return ErrOAuthStateMismatch
+	}
+	return nil
+}
+
+func (s *CookieOAuthStateStore) sign(value string) string {
+	mac := hmac.New(sha256.New, []byte(s.Secret))
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// OAuthTokenFunc receives the access token of a shop that installed the app.
+// It must write the response, e.g. redirect to the app.
+type OAuthTokenFunc func(w http.ResponseWriter, r *http.Request, shop string, token *AccessToken)
+
+// OAuthHandler runs the OAuth flow to install the app on a shop. Serve
+// InstallHandler at the URL of the app and CallbackHandler at the
+// RedirectUrl of the App.
+// See: https://help.shopify.com/api/guides/authentication/oauth
+type OAuthHandler struct {
+	// Logger, if set, is used to report rejected requests.
+	Logger Logger
+
+	// StateStore keeps the nonces of started authorizations.
+	StateStore OAuthStateStore
+
+	// MaxAge is how old the timestamp of a request from Shopify may be, or
+	// how far it may be in the future.
+	MaxAge time.Duration
+
+	// Online requests online access tokens, tied to the staff member that
+	// authorizes the app, instead of offline ones.
+	Online bool
+
+	app     App
+	onToken OAuthTokenFunc
+}
+
+// NewOAuthHandler returns an OAuthHandler for the app that passes access
+// tokens to onToken. Nonces are kept in cookies signed with the ApiSecret of
+// the app and requests may be DefaultOAuthMaxAge old.
+func NewOAuthHandler(app App, onToken OAuthTokenFunc) *OAuthHandler {
+	return &OAuthHandler{
+		StateStore: NewCookieOAuthStateStore(app.ApiSecret),
+		MaxAge:     DefaultOAuthMaxAge,
+		app:        app,
+		onToken:    onToken,
+	}
+}
+
+// InstallHandler returns a handler that redirects to the authorization page
+// of the shop in the shop parameter, with a new nonce as the state. If the
+// request is signed by Shopify, as when installing from the admin, the
+// signature is verified.
+func (h *OAuthHandler) InstallHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		shop := r.URL.Query().Get("shop")
+		if err := ValidateShopDomain(shop, h.app.ShopDomainSuffixes...); err != nil {
+			h.reject(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		shop = strings.ToLower(shop)
+		if r.URL.Query().Get("hmac") != "" {
+			if status, err := h.verifyRequest(r); err != nil {
+				h.reject(w, status, "%v", err)
+				return
+			}
+		}
+
+		nonce, err := newOAuthNonce()
+		if err != nil {
+			h.reject(w, http.StatusInternalServerError, "generating nonce: %v", err)
+			return
+		}
+		if err := h.StateStore.Save(w, r, shop, nonce); err != nil {
+			h.reject(w, http.StatusInternalServerError, "saving state of %s: %v", shop, err)
+			return
+		}
+		authorizeUrl := h.app.AuthorizeUrl
+		if h.Online {
+			authorizeUrl = h.app.OnlineAuthorizeUrl
+		}
+		authUrl, err := authorizeUrl(shop, nonce)
+		if err != nil {
+			h.reject(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		http.Redirect(w, r, authUrl, http.StatusFound)
+	})
+}
+
+// CallbackHandler returns a handler for the redirect from Shopify after the
+// shop authorized the app. It verifies the shop, HMAC, timestamp and state
+// of the request, exchanges the code for an access token and passes it on.
+func (h *OAuthHandler) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		shop := q.Get("shop")
+		if err := ValidateShopDomain(shop, h.app.ShopDomainSuffixes...); err != nil {
+			h.reject(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		shop = strings.ToLower(shop)
+		if status, err := h.verifyRequest(r); err != nil {
+			h.reject(w, status, "%v", err)
+			return
+		}
+		if err := h.StateStore.Verify(w, r, shop, q.Get("state")); err != nil {
+			h.reject(w, http.StatusForbidden, "verifying state of %s: %v", shop, err)
+			return
+		}
+
+		token, err := h.app.RequestAccessTokenWithContext(r.Context(), shop, q.Get("code"))
+		if err != nil {
+			h.reject(w, http.StatusBadGateway, "getting access token of %s: %v", shop, err)
+			return
+		}
+		h.onToken(w, r, shop, token)
+	})
+}
+
+// verifyRequest verifies the HMAC and timestamp of a request from Shopify
+// and returns the status to reject it with if they don't.
+func (h *OAuthHandler) verifyRequest(r *http.Request) (int, error) {
+	ok, err := h.app.VerifyAuthorizationURL(r.URL)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if !ok {
+		return http.StatusUnauthorized, errors.New("goshopify: oauth hmac mismatch")
+	}
+
+	timestamp, err := strconv.ParseInt(r.URL.Query().Get("timestamp"), 10, 64)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("goshopify: invalid timestamp %q", r.URL.Query().Get("timestamp"))
+	}
+	age := time.Since(time.Unix(timestamp, 0))
+	if h.MaxAge > 0 && (age > h.MaxAge || age < -h.MaxAge) {
+		return http.StatusBadRequest, fmt.Errorf("goshopify: timestamp %d is too old", timestamp)
+	}
+	return 0, nil
+}
+
+func (h *OAuthHandler) reject(w http.ResponseWriter, status int, format string, v ...interface{}) {
+	if h.Logger != nil {
+		h.Logger.Printf("goshopify: oauth request rejected: "+format, v...)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// newOAuthNonce returns a random nonce for the state of an authorization.
+func newOAuthNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,245 @@
+package goshopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+// oauthRequest returns a request to the path with the query signed like
+// Shopify does with the secret.
+func oauthRequest(secret, path string, query url.Values) *http.Request {
+	message, _ := url.QueryUnescape(query.Encode())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	query.Set("hmac", hex.EncodeToString(mac.Sum(nil)))
+	return httptest.NewRequest("GET", path+"?"+query.Encode(), nil)
+}
+
+func oauthCallbackQuery(shop, state string) url.Values {
+	return url.Values{
+		"code":      {"foocode"},
+		"shop":      {shop},
+		"state":     {state},
+		"timestamp": {strconv.FormatInt(time.Now().Unix(), 10)},
+	}
+}
+
+type oauthInstall struct {
+	shop, token string
+}
+
+func newTestOAuthHandler(installs *[]oauthInstall) *OAuthHandler {
+	return NewOAuthHandler(app, func(w http.ResponseWriter, r *http.Request, shop string, token *AccessToken) {
+		*installs = append(*installs, oauthInstall{shop, token.Token})
+		http.Redirect(w, r, "https://example.com/app", http.StatusFound)
+	})
+}
+
+// startOAuth runs the install handler for the shop and returns the state and
+// cookie it sets.
+func startOAuth(t *testing.T, h *OAuthHandler, shop string) (string, *http.Cookie) {
+	w := httptest.NewRecorder()
+	h.InstallHandler().ServeHTTP(w, httptest.NewRequest("GET", "/install?shop="+shop, nil))
+	if w.Code != http.StatusFound {
+		t.Fatalf("InstallHandler responded %d, expected %d", w.Code, http.StatusFound)
+	}
+
+	location, _ := url.Parse(w.Header().Get("Location"))
+	state := location.Query().Get("state")
+	expected, _ := app.AuthorizeUrl(shop, state)
+	if location.String() != expected || len(state) != 32 {
+		t.Errorf("InstallHandler redirected to %s, expected %s with a nonce", location, expected)
+	}
+
+	cookies := (&http.Response{Header: w.Header()}).Cookies()
+	if len(cookies) != 1 || cookies[0].Name != DefaultOAuthStateCookie || !cookies[0].HttpOnly || !cookies[0].Secure {
+		t.Fatalf("InstallHandler set cookies %v, expected a secure state cookie", cookies)
+	}
+	return state, cookies[0]
+}
+
+func TestOAuthHandler(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(200, `{"access_token":"footoken"}`))
+
+	var installs []oauthInstall
+	h := newTestOAuthHandler(&installs)
+	state, cookie := startOAuth(t, h, "fooshop.myshopify.com")
+
+	r := oauthRequest("hush", "/callback", oauthCallbackQuery("fooshop.myshopify.com", state))
+	r.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	h.CallbackHandler().ServeHTTP(w, r)
+
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "https://example.com/app" {
+		t.Errorf("CallbackHandler responded %d to %s, expected the callback's redirect", w.Code, w.Header().Get("Location"))
+	}
+	if len(installs) != 1 || installs[0] != (oauthInstall{"fooshop.myshopify.com", "footoken"}) {
+		t.Errorf("CallbackHandler passed on %v, expected the token of fooshop", installs)
+	}
+	if !strings.Contains(w.Header().Get("Set-Cookie"), "Max-Age=0") {
+		t.Errorf("CallbackHandler set cookie %q, expected the state cookie to be cleared", w.Header().Get("Set-Cookie"))
+	}
+}
+
+func TestOAuthHandlerShopCase(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(200, `{"access_token":"footoken"}`))
+
+	var installs []oauthInstall
+	h := newTestOAuthHandler(&installs)
+	state, cookie := startOAuth(t, h, "FooShop.MyShopify.com")
+
+	r := oauthRequest("hush", "/callback", oauthCallbackQuery("fooshop.MYSHOPIFY.com", state))
+	r.AddCookie(cookie)
+	w := httptest.NewRecorder()
+	h.CallbackHandler().ServeHTTP(w, r)
+
+	if len(installs) != 1 || installs[0] != (oauthInstall{"fooshop.myshopify.com", "footoken"}) {
+		t.Errorf("CallbackHandler passed on %v, expected the token of fooshop.myshopify.com", installs)
+	}
+}
+
+func TestOAuthHandlerInstallSigned(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var installs []oauthInstall
+	h := newTestOAuthHandler(&installs)
+
+	query := url.Values{"shop": {"fooshop.myshopify.com"}, "timestamp": {strconv.FormatInt(time.Now().Unix(), 10)}}
+	w := httptest.NewRecorder()
+	h.InstallHandler().ServeHTTP(w, oauthRequest("hush", "/install", query))
+	if w.Code != http.StatusFound {
+		t.Errorf("InstallHandler responded %d to a signed request, expected %d", w.Code, http.StatusFound)
+	}
+
+	w = httptest.NewRecorder()
+	h.InstallHandler().ServeHTTP(w, oauthRequest("wrong", "/install", query))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("InstallHandler responded %d to a badly signed request, expected %d", w.Code, http.StatusUnauthorized)
+	}
+
+	w = httptest.NewRecorder()
+	h.InstallHandler().ServeHTTP(w, httptest.NewRequest("GET", "/install?shop=evil.com", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("InstallHandler responded %d to an invalid shop, expected %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestOAuthHandlerCallbackRejected(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(200, `{"access_token":"footoken"}`))
+	httpmock.RegisterResponder("POST", "https://barshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(400, `{"error":"invalid_request"}`))
+
+	var installs []oauthInstall
+	h := newTestOAuthHandler(&installs)
+	state, cookie := startOAuth(t, h, "fooshop.myshopify.com")
+	barState, barCookie := startOAuth(t, h, "barshop.myshopify.com")
+
+	staleQuery := oauthCallbackQuery("fooshop.myshopify.com", state)
+	staleQuery.Set("timestamp", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+
+	tampered := *cookie
+	tampered.Value = strings.Replace(tampered.Value, "fooshop", "barshop", 1)
+
+	cases := []struct {
+		description string
+		secret      string
+		query       url.Values
+		cookie      *http.Cookie
+		expected    int
+	}{
+		{"invalid shop", "hush", oauthCallbackQuery("fooshop.evil.com", state), cookie, http.StatusBadRequest},
+		{"invalid hmac", "wrong", oauthCallbackQuery("fooshop.myshopify.com", state), cookie, http.StatusUnauthorized},
+		{"stale timestamp", "hush", staleQuery, cookie, http.StatusBadRequest},
+		{"missing cookie", "hush", oauthCallbackQuery("fooshop.myshopify.com", state), nil, http.StatusForbidden},
+		{"wrong state", "hush", oauthCallbackQuery("fooshop.myshopify.com", barState), cookie, http.StatusForbidden},
+		{"cookie of other shop", "hush", oauthCallbackQuery("fooshop.myshopify.com", barState), barCookie, http.StatusForbidden},
+		{"tampered cookie", "hush", oauthCallbackQuery("barshop.myshopify.com", state), &tampered, http.StatusForbidden},
+		{"token exchange failed", "hush", oauthCallbackQuery("barshop.myshopify.com", barState), barCookie, http.StatusBadGateway},
+	}
+
+	for _, c := range cases {
+		r := oauthRequest(c.secret, "/callback", c.query)
+		if c.cookie != nil {
+			r.AddCookie(c.cookie)
+		}
+		w := httptest.NewRecorder()
+		h.CallbackHandler().ServeHTTP(w, r)
+		if w.Code != c.expected {
+			t.Errorf("CallbackHandler responded %d to %s, expected %d", w.Code, c.description, c.expected)
+		}
+	}
+	if len(installs) != 0 {
+		t.Errorf("CallbackHandler passed on %v, expected nothing", installs)
+	}
+}
+
+func TestCookieOAuthStateStoreExpired(t *testing.T) {
+	store := NewCookieOAuthStateStore("hush")
+	store.TTL = -time.Minute
+
+	w := httptest.NewRecorder()
+	store.Save(w, httptest.NewRequest("GET", "/install", nil), "fooshop.myshopify.com", "thenonce")
+
+	r := httptest.NewRequest("GET", "/callback", nil)
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		r.AddCookie(cookie)
+	}
+	err := store.Verify(httptest.NewRecorder(), r, "fooshop.myshopify.com", "thenonce")
+	if err != ErrOAuthStateMismatch {
+		t.Errorf("CookieOAuthStateStore.Verify returned %v, expected %v", err, ErrOAuthStateMismatch)
+	}
+}
+
+func TestOAuthHandlerOnline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		httpmock.NewStringResponder(200, `{"access_token":"footoken","expires_in":86399,"associated_user":{"id":1}}`))
+
+	var token *AccessToken
+	h := NewOAuthHandler(app, func(w http.ResponseWriter, r *http.Request, shop string, t *AccessToken) {
+		token = t
+	})
+	h.Online = true
+
+	w := httptest.NewRecorder()
+	h.InstallHandler().ServeHTTP(w, httptest.NewRequest("GET", "/install?shop=fooshop.myshopify.com", nil))
+	location, _ := url.Parse(w.Header().Get("Location"))
+	state := location.Query().Get("state")
+	if location.Query().Get("grant_options[]") != "per-user" {
+		t.Errorf("InstallHandler redirected to %s, expected an online authorization", location)
+	}
+
+	r := oauthRequest("hush", "/callback", oauthCallbackQuery("fooshop.myshopify.com", state))
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		r.AddCookie(cookie)
+	}
+	h.CallbackHandler().ServeHTTP(httptest.NewRecorder(), r)
+	if token == nil || !token.Online() || token.ExpiresAt.IsZero() {
+		t.Errorf("CallbackHandler passed on %+v, expected an online token", token)
+	}
+}
@@ -0,0 +1,99 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Option configures a Client created with NewClientWithOptions.
+type Option func(c *Client) error
+
+// Logger is used by the client to report retries and warnings from Shopify.
+// The standard library's *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithHTTPClient makes the client send its requests through the given
+// http.Client instead of http.DefaultClient, e.g. to use a custom transport
+// or timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return fmt.Errorf("goshopify: nil http client")
+		}
+		c.Client = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL makes the client send its requests to the given base URL instead
+// of the shop's myshopify.com URL, e.g. to point it at a local stand-in server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("goshopify: base url %q must be absolute", baseURL)
+		}
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithRetry makes the client retry rate limited and failed requests according
+// to the given policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) error {
+		c.Retry = &policy
+		return nil
+	}
+}
+
+// WithRateLimiter makes the client wait for the given rate limiter before
+// sending requests. See RateLimiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) error {
+		c.RateLimiter = limiter
+		return nil
+	}
+}
+
+// WithLogger makes the client log retries and warnings to the given logger.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithVersion sets the Admin API version the client talks to, e.g.
+// "2019-04". Requests for "admin/..." paths are sent to the matching
+// "admin/api/{version}/..." path.
+func WithVersion(version string) Option {
+	return func(c *Client) error {
+		if err := ValidateAPIVersion(version); err != nil {
+			return err
+		}
+		c.apiVersion = version
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// logf logs to the client's logger, if it has one.
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
@@ -0,0 +1,129 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+	limiter := NewRateLimiter(DefaultCallLimitMax, DefaultCallLimitLeakRate)
+	logger := &testLogger{}
+
+	c, err := NewClientWithOptions(app, "fooshop", "abcd",
+		WithHTTPClient(httpClient),
+		WithBaseURL("http://127.0.0.1:8080"),
+		WithRetry(DefaultRetryPolicy),
+		WithRateLimiter(limiter),
+		WithLogger(logger),
+		WithVersion("2019-04"),
+		WithUserAgent("myapp/1.0"),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() err = %v, expected nil", err)
+	}
+
+	if c.Client != httpClient {
+		t.Errorf("WithHTTPClient() Client = %v, expected %v", c.Client, httpClient)
+	}
+	if c.baseURL.String() != "http://127.0.0.1:8080" {
+		t.Errorf("WithBaseURL() BaseURL = %v, expected %v", c.baseURL, "http://127.0.0.1:8080")
+	}
+	if c.Retry == nil || *c.Retry != DefaultRetryPolicy {
+		t.Errorf("WithRetry() Retry = %v, expected %v", c.Retry, DefaultRetryPolicy)
+	}
+	if c.RateLimiter != limiter {
+		t.Errorf("WithRateLimiter() RateLimiter = %v, expected %v", c.RateLimiter, limiter)
+	}
+	if c.logger != logger {
+		t.Errorf("WithLogger() logger = %v, expected %v", c.logger, logger)
+	}
+	if c.apiVersion != "2019-04" {
+		t.Errorf("WithVersion() apiVersion = %v, expected %v", c.apiVersion, "2019-04")
+	}
+
+	req, _ := c.NewRequest("GET", "foo", nil, nil)
+	if ua := req.Header.Get("User-Agent"); ua != "myapp/1.0" {
+		t.Errorf("WithUserAgent() User-Agent = %v, expected %v", ua, "myapp/1.0")
+	}
+}
+
+func TestNewClientWithOptionsError(t *testing.T) {
+	cases := []Option{
+		WithHTTPClient(nil),
+		WithBaseURL("://example.com"),
+		WithBaseURL("example.com"),
+	}
+
+	for _, opt := range cases {
+		c, err := NewClientWithOptions(app, "fooshop", "abcd", opt)
+		if err == nil || c != nil {
+			t.Errorf("NewClientWithOptions() = %v, %v, expected an error", c, err)
+		}
+	}
+}
+
+func TestWithBaseURLServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/shop.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"shop": {"id": 1}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(app, "fooshop", "abcd", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() err = %v, expected nil", err)
+	}
+
+	shop, err := c.Shop.Get(nil)
+	if err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+	if shop.ID != 1 {
+		t.Errorf("Shop.ID returned %d, expected %d", shop.ID, 1)
+	}
+}
+
+func TestWithLoggerRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	c, _ := NewClientWithOptions(app, "fooshop", "abcd",
+		WithBaseURL(server.URL),
+		WithRetry(RetryPolicy{MaxAttempts: 2}),
+		WithLogger(logger),
+	)
+
+	err := c.Get("foo", nil, nil)
+	if err != nil {
+		t.Fatalf("Client.Get returned error: %v", err)
+	}
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "retrying GET") {
+		t.Errorf("Client.Get logged %v, expected a retry", logger.lines)
+	}
+}
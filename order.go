@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,10 +16,20 @@ const ordersResourceName = "orders"
 // See: https://help.shopify.com/api/reference/order
 type OrderService interface {
 	List(interface{}) ([]Order, error)
+	ListPage(interface{}) ([]Order, *Pagination, error)
+	ListAll(interface{}) ([]Order, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Order, error)
 	Create(Order) (*Order, error)
 
+	ListWithContext(context.Context, interface{}) ([]Order, error)
+	ListPageWithContext(context.Context, interface{}) ([]Order, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Order, error)
+	Iterate(context.Context, interface{}) <-chan OrderResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Order, error)
+	CreateWithContext(context.Context, Order) (*Order, error)
+
 	// MetafieldsService used for Order resource to communicate with Metafields resource
 	MetafieldsService
 
@@ -206,6 +217,12 @@ type OrdersResource struct {
 	Orders []Order `json:"orders"`
 }
 
+// OrderResult is an order or an error from Iterate.
+type OrderResult struct {
+	Order Order
+	Err   error
+}
+
 type PaymentDetails struct {
 	AVSResultCode     string `json:"avs_result_code,omitempty"`
 	CreditCardBin     string `json:"credit_card_bin,omitempty"`
@@ -283,115 +300,267 @@ type RefundLineItem struct {
 
 // List orders
 func (s *OrderServiceOp) List(options interface{}) ([]Order, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *OrderServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Order, error) {
 	path := fmt.Sprintf("%s.json", ordersBasePath)
 	resource := new(OrdersResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Orders, err
 }
 
+// ListPage lists a page of orders along with its pagination
+func (s *OrderServiceOp) ListPage(options interface{}) ([]Order, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *OrderServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Order, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", ordersBasePath)
+	resource := new(OrdersResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Orders, pagination, err
+}
+
+// ListAll lists all orders, following the pagination from the given options
+func (s *OrderServiceOp) ListAll(options interface{}) ([]Order, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *OrderServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Order, error) {
+	var collector []Order
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		orders, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, orders...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all orders over the returned channel, fetching the pages
+// lazily. The channel is closed after the last order or after a result
+// with an error. Cancel ctx to stop early.
+func (s *OrderServiceOp) Iterate(ctx context.Context, options interface{}) <-chan OrderResult {
+	results := make(chan OrderResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			orders, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, order := range orders {
+				select {
+				case results <- OrderResult{Order: order}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- OrderResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count orders
 func (s *OrderServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *OrderServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", ordersBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual order
 func (s *OrderServiceOp) Get(orderID int, options interface{}) (*Order, error) {
+	return s.GetWithContext(context.Background(), orderID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *OrderServiceOp) GetWithContext(ctx context.Context, orderID int, options interface{}) (*Order, error) {
 	path := fmt.Sprintf("%s/%d.json", ordersBasePath, orderID)
 	resource := new(OrderResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Order, err
 }
 
 // Create order
 func (s *OrderServiceOp) Create(order Order) (*Order, error) {
+	return s.CreateWithContext(context.Background(), order)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *OrderServiceOp) CreateWithContext(ctx context.Context, order Order) (*Order, error) {
 	path := fmt.Sprintf("%s.json", ordersBasePath)
 	wrappedData := OrderResource{Order: &order}
 	resource := new(OrderResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Order, err
 }
 
 // List metafields for an order
 func (s *OrderServiceOp) ListMetafields(orderID int, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), orderID, options)
+}
+
+// ListMetafieldsWithContext is like ListMetafields but uses ctx for the request.
+func (s *OrderServiceOp) ListMetafieldsWithContext(ctx context.Context, orderID int, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return metafieldService.List(options)
+	return metafieldService.ListWithContext(ctx, options)
 }
 
 // Count metafields for an order
 func (s *OrderServiceOp) CountMetafields(orderID int, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), orderID, options)
+}
+
+// CountMetafieldsWithContext is like CountMetafields but uses ctx for the request.
+func (s *OrderServiceOp) CountMetafieldsWithContext(ctx context.Context, orderID int, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return metafieldService.Count(options)
+	return metafieldService.CountWithContext(ctx, options)
 }
 
 // Get individual metafield for an order
 func (s *OrderServiceOp) GetMetafield(orderID int, metafieldID int, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), orderID, metafieldID, options)
+}
+
+// GetMetafieldWithContext is like GetMetafield but uses ctx for the request.
+func (s *OrderServiceOp) GetMetafieldWithContext(ctx context.Context, orderID int, metafieldID int, options interface{}) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return metafieldService.Get(metafieldID, options)
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
 }
 
 // Create a new metafield for an order
 func (s *OrderServiceOp) CreateMetafield(orderID int, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), orderID, metafield)
+}
+
+// CreateMetafieldWithContext is like CreateMetafield but uses ctx for the request.
+func (s *OrderServiceOp) CreateMetafieldWithContext(ctx context.Context, orderID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return metafieldService.Create(metafield)
+	return metafieldService.CreateWithContext(ctx, metafield)
 }
 
 // Update an existing metafield for an order
 func (s *OrderServiceOp) UpdateMetafield(orderID int, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), orderID, metafield)
+}
+
+// UpdateMetafieldWithContext is like UpdateMetafield but uses ctx for the request.
+func (s *OrderServiceOp) UpdateMetafieldWithContext(ctx context.Context, orderID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return metafieldService.Update(metafield)
+	return metafieldService.UpdateWithContext(ctx, metafield)
 }
 
 // Delete an existing metafield for an order
 func (s *OrderServiceOp) DeleteMetafield(orderID int, metafieldID int) error {
+	return s.DeleteMetafieldWithContext(context.Background(), orderID, metafieldID)
+}
+
+// DeleteMetafieldWithContext is like DeleteMetafield but uses ctx for the request.
+func (s *OrderServiceOp) DeleteMetafieldWithContext(ctx context.Context, orderID int, metafieldID int) error {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return metafieldService.Delete(metafieldID)
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
 }
 
 // List fulfillments for an order
 func (s *OrderServiceOp) ListFulfillments(orderID int, options interface{}) ([]Fulfillment, error) {
+	return s.ListFulfillmentsWithContext(context.Background(), orderID, options)
+}
+
+// ListFulfillmentsWithContext is like ListFulfillments but uses ctx for the request.
+func (s *OrderServiceOp) ListFulfillmentsWithContext(ctx context.Context, orderID int, options interface{}) ([]Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.List(options)
+	return fulfillmentService.ListWithContext(ctx, options)
 }
 
 // Count fulfillments for an order
 func (s *OrderServiceOp) CountFulfillments(orderID int, options interface{}) (int, error) {
+	return s.CountFulfillmentsWithContext(context.Background(), orderID, options)
+}
+
+// CountFulfillmentsWithContext is like CountFulfillments but uses ctx for the request.
+func (s *OrderServiceOp) CountFulfillmentsWithContext(ctx context.Context, orderID int, options interface{}) (int, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Count(options)
+	return fulfillmentService.CountWithContext(ctx, options)
 }
 
 // Get individual fulfillment for an order
 func (s *OrderServiceOp) GetFulfillment(orderID int, fulfillmentID int, options interface{}) (*Fulfillment, error) {
+	return s.GetFulfillmentWithContext(context.Background(), orderID, fulfillmentID, options)
+}
+
+// GetFulfillmentWithContext is like GetFulfillment but uses ctx for the request.
+func (s *OrderServiceOp) GetFulfillmentWithContext(ctx context.Context, orderID int, fulfillmentID int, options interface{}) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Get(fulfillmentID, options)
+	return fulfillmentService.GetWithContext(ctx, fulfillmentID, options)
 }
 
 // Create a new fulfillment for an order
 func (s *OrderServiceOp) CreateFulfillment(orderID int, fulfillment Fulfillment) (*Fulfillment, error) {
+	return s.CreateFulfillmentWithContext(context.Background(), orderID, fulfillment)
+}
+
+// CreateFulfillmentWithContext is like CreateFulfillment but uses ctx for the request.
+func (s *OrderServiceOp) CreateFulfillmentWithContext(ctx context.Context, orderID int, fulfillment Fulfillment) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Create(fulfillment)
+	return fulfillmentService.CreateWithContext(ctx, fulfillment)
 }
 
 // Update an existing fulfillment for an order
 func (s *OrderServiceOp) UpdateFulfillment(orderID int, fulfillment Fulfillment) (*Fulfillment, error) {
+	return s.UpdateFulfillmentWithContext(context.Background(), orderID, fulfillment)
+}
+
+// UpdateFulfillmentWithContext is like UpdateFulfillment but uses ctx for the request.
+func (s *OrderServiceOp) UpdateFulfillmentWithContext(ctx context.Context, orderID int, fulfillment Fulfillment) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Update(fulfillment)
+	return fulfillmentService.UpdateWithContext(ctx, fulfillment)
 }
 
 // Complete an existing fulfillment for an order
 func (s *OrderServiceOp) CompleteFulfillment(orderID int, fulfillmentID int) (*Fulfillment, error) {
+	return s.CompleteFulfillmentWithContext(context.Background(), orderID, fulfillmentID)
+}
+
+// CompleteFulfillmentWithContext is like CompleteFulfillment but uses ctx for the request.
+func (s *OrderServiceOp) CompleteFulfillmentWithContext(ctx context.Context, orderID int, fulfillmentID int) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Complete(fulfillmentID)
+	return fulfillmentService.CompleteWithContext(ctx, fulfillmentID)
 }
 
 // Transition an existing fulfillment for an order
 func (s *OrderServiceOp) TransitionFulfillment(orderID int, fulfillmentID int) (*Fulfillment, error) {
+	return s.TransitionFulfillmentWithContext(context.Background(), orderID, fulfillmentID)
+}
+
+// TransitionFulfillmentWithContext is like TransitionFulfillment but uses ctx for the request.
+func (s *OrderServiceOp) TransitionFulfillmentWithContext(ctx context.Context, orderID int, fulfillmentID int) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Transition(fulfillmentID)
+	return fulfillmentService.TransitionWithContext(ctx, fulfillmentID)
 }
 
 // Cancel an existing fulfillment for an order
 func (s *OrderServiceOp) CancelFulfillment(orderID int, fulfillmentID int) (*Fulfillment, error) {
+	return s.CancelFulfillmentWithContext(context.Background(), orderID, fulfillmentID)
+}
+
+// CancelFulfillmentWithContext is like CancelFulfillment but uses ctx for the request.
+func (s *OrderServiceOp) CancelFulfillmentWithContext(ctx context.Context, orderID int, fulfillmentID int) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Cancel(fulfillmentID)
+	return fulfillmentService.CancelWithContext(ctx, fulfillmentID)
 }
@@ -1,6 +1,8 @@
 package goshopify
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -112,6 +114,86 @@ func TestOrderListOptions(t *testing.T) {
 	orderTests(t, order)
 }
 
+func TestOrderIterate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/orders.json?limit=2",
+		linkResponder(`{"orders": [{"id":1},{"id":2}]}`,
+			`<https://fooshop.myshopify.com/admin/orders.json?limit=2&page_info=abc>; rel="next"`))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/orders.json?limit=2&page_info=abc",
+		linkResponder(`{"orders": [{"id":3}]}`, ``))
+
+	var orders []Order
+	for result := range client.Order.Iterate(context.Background(), ListOptions{Limit: 2}) {
+		if result.Err != nil {
+			t.Fatalf("Order.Iterate returned error: %v", result.Err)
+		}
+		orders = append(orders, result.Order)
+	}
+
+	expected := []Order{{ID: 1}, {ID: 2}, {ID: 3}}
+	if !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Order.Iterate returned %+v, expected %+v", orders, expected)
+	}
+}
+
+func TestOrderIterateError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/orders.json",
+		linkResponder(`{"orders": [{"id":1}]}`,
+			`<https://fooshop.myshopify.com/admin/orders.json?page_info=abc>; rel="next"`))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/orders.json?page_info=abc",
+		httpmock.NewErrorResponder(errors.New("connection reset")))
+
+	var results []OrderResult
+	for result := range client.Order.Iterate(context.Background(), nil) {
+		results = append(results, result)
+	}
+
+	if len(results) != 2 || results[0].Order.ID != 1 || results[1].Err == nil {
+		t.Errorf("Order.Iterate returned %+v, expected an order followed by an error", results)
+	}
+}
+
+func TestOrderIterateStop(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/orders.json",
+		linkResponder(`{"orders": [{"id":1},{"id":2},{"id":3}]}`,
+			`<https://fooshop.myshopify.com/admin/orders.json?page_info=abc>; rel="next"`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := client.Order.Iterate(ctx, nil)
+
+	first := <-results
+	if first.Order.ID != 1 {
+		t.Errorf("Order.Iterate returned %+v, expected order 1", first)
+	}
+
+	// Stop early, the producer must close the channel instead of blocking
+	cancel()
+	select {
+	case <-drainOrderResults(results):
+	case <-time.After(time.Second):
+		t.Errorf("Order.Iterate did not stop after cancelling the context")
+	}
+}
+
+// drainOrderResults reads all results and signals when the channel is closed.
+func drainOrderResults(results <-chan OrderResult) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		for range results {
+		}
+		close(done)
+	}()
+	return done
+}
+
 func TestOrderGet(t *testing.T) {
 	setup()
 	defer teardown()
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -13,12 +14,24 @@ const pagesResourceName = "pages"
 // See https://help.shopify.com/api/reference/online_store/page
 type PageService interface {
 	List(interface{}) ([]Page, error)
+	ListPage(interface{}) ([]Page, *Pagination, error)
+	ListAll(interface{}) ([]Page, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Page, error)
 	Create(Page) (*Page, error)
 	Update(Page) (*Page, error)
 	Delete(int) error
 
+	ListWithContext(context.Context, interface{}) ([]Page, error)
+	ListPageWithContext(context.Context, interface{}) ([]Page, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Page, error)
+	Iterate(context.Context, interface{}) <-chan PageResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Page, error)
+	CreateWithContext(context.Context, Page) (*Page, error)
+	UpdateWithContext(context.Context, Page) (*Page, error)
+	DeleteWithContext(context.Context, int) error
+
 	// MetafieldsService used for Pages resource to communicate with Metafields
 	// resource
 	MetafieldsService
@@ -55,83 +68,211 @@ type PagesResource struct {
 	Pages []Page `json:"pages"`
 }
 
+// PageResult is a page or an error from Iterate.
+type PageResult struct {
+	Page Page
+	Err  error
+}
+
 // List pages
 func (s *PageServiceOp) List(options interface{}) ([]Page, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *PageServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Page, error) {
 	path := fmt.Sprintf("%s.json", pagesBasePath)
 	resource := new(PagesResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Pages, err
 }
 
+// ListPage lists a page of pages along with its pagination
+func (s *PageServiceOp) ListPage(options interface{}) ([]Page, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *PageServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Page, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", pagesBasePath)
+	resource := new(PagesResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Pages, pagination, err
+}
+
+// ListAll lists all pages, following the pagination from the given options
+func (s *PageServiceOp) ListAll(options interface{}) ([]Page, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *PageServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Page, error) {
+	var collector []Page
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		pages, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, pages...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all pages over the returned channel, fetching the pages
+// lazily. The channel is closed after the last page or after a result
+// with an error. Cancel ctx to stop early.
+func (s *PageServiceOp) Iterate(ctx context.Context, options interface{}) <-chan PageResult {
+	results := make(chan PageResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			pages, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, page := range pages {
+				select {
+				case results <- PageResult{Page: page}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- PageResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count pages
 func (s *PageServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *PageServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", pagesBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual page
 func (s *PageServiceOp) Get(pageID int, options interface{}) (*Page, error) {
+	return s.GetWithContext(context.Background(), pageID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *PageServiceOp) GetWithContext(ctx context.Context, pageID int, options interface{}) (*Page, error) {
 	path := fmt.Sprintf("%s/%d.json", pagesBasePath, pageID)
 	resource := new(PageResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Page, err
 }
 
 // Create a new page
 func (s *PageServiceOp) Create(page Page) (*Page, error) {
+	return s.CreateWithContext(context.Background(), page)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *PageServiceOp) CreateWithContext(ctx context.Context, page Page) (*Page, error) {
 	path := fmt.Sprintf("%s.json", pagesBasePath)
 	wrappedData := PageResource{Page: &page}
 	resource := new(PageResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Page, err
 }
 
 // Update an existing page
 func (s *PageServiceOp) Update(page Page) (*Page, error) {
+	return s.UpdateWithContext(context.Background(), page)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *PageServiceOp) UpdateWithContext(ctx context.Context, page Page) (*Page, error) {
 	path := fmt.Sprintf("%s/%d.json", pagesBasePath, page.ID)
 	wrappedData := PageResource{Page: &page}
 	resource := new(PageResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Page, err
 }
 
 // Delete an existing page.
 func (s *PageServiceOp) Delete(pageID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", pagesBasePath, pageID))
+	return s.DeleteWithContext(context.Background(), pageID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *PageServiceOp) DeleteWithContext(ctx context.Context, pageID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", pagesBasePath, pageID))
 }
 
 // List metafields for a page
 func (s *PageServiceOp) ListMetafields(pageID int, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), pageID, options)
+}
+
+// ListMetafieldsWithContext is like ListMetafields but uses ctx for the request.
+func (s *PageServiceOp) ListMetafieldsWithContext(ctx context.Context, pageID int, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceID: pageID}
-	return metafieldService.List(options)
+	return metafieldService.ListWithContext(ctx, options)
 }
 
 // Count metafields for a page
 func (s *PageServiceOp) CountMetafields(pageID int, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), pageID, options)
+}
+
+// CountMetafieldsWithContext is like CountMetafields but uses ctx for the request.
+func (s *PageServiceOp) CountMetafieldsWithContext(ctx context.Context, pageID int, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceID: pageID}
-	return metafieldService.Count(options)
+	return metafieldService.CountWithContext(ctx, options)
 }
 
 // Get individual metafield for a page
 func (s *PageServiceOp) GetMetafield(pageID int, metafieldID int, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), pageID, metafieldID, options)
+}
+
+// GetMetafieldWithContext is like GetMetafield but uses ctx for the request.
+func (s *PageServiceOp) GetMetafieldWithContext(ctx context.Context, pageID int, metafieldID int, options interface{}) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceID: pageID}
-	return metafieldService.Get(metafieldID, options)
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
 }
 
 // Create a new metafield for a page
 func (s *PageServiceOp) CreateMetafield(pageID int, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), pageID, metafield)
+}
+
+// CreateMetafieldWithContext is like CreateMetafield but uses ctx for the request.
+func (s *PageServiceOp) CreateMetafieldWithContext(ctx context.Context, pageID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceID: pageID}
-	return metafieldService.Create(metafield)
+	return metafieldService.CreateWithContext(ctx, metafield)
 }
 
 // Update an existing metafield for a page
 func (s *PageServiceOp) UpdateMetafield(pageID int, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), pageID, metafield)
+}
+
+// UpdateMetafieldWithContext is like UpdateMetafield but uses ctx for the request.
+func (s *PageServiceOp) UpdateMetafieldWithContext(ctx context.Context, pageID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceID: pageID}
-	return metafieldService.Update(metafield)
+	return metafieldService.UpdateWithContext(ctx, metafield)
 }
 
 // Delete an existing metafield for a page
 func (s *PageServiceOp) DeleteMetafield(pageID int, metafieldID int) error {
+	return s.DeleteMetafieldWithContext(context.Background(), pageID, metafieldID)
+}
+
+// DeleteMetafieldWithContext is like DeleteMetafield but uses ctx for the request.
+func (s *PageServiceOp) DeleteMetafieldWithContext(ctx context.Context, pageID int, metafieldID int) error {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: pagesResourceName, resourceID: pageID}
-	return metafieldService.Delete(metafieldID)
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
 }
@@ -0,0 +1,130 @@
+package goshopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// linkRegex matches the links of a Link header, e.g.
+// <https://theshop.myshopify.com/admin/products.json?page_info=abc>; rel="next"
+// The URLs may contain unescaped commas, e.g. in the fields parameter, so the
+// header is not split on them.
+var linkRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="(next|previous)"`)
+
+// Pagination holds the options for fetching the pages before and after a page
+// of results. Shopify returns them in the Link header of cursor-paginated list
+// endpoints. The options are nil on the first and last page respectively.
+type Pagination struct {
+	NextPageOptions     *ListOptions
+	PreviousPageOptions *ListOptions
+}
+
+// parsePagination parses the pagination from a Link header.
+func parsePagination(linkHeader string) (*Pagination, error) {
+	pagination := new(Pagination)
+
+	if linkHeader == "" {
+		return pagination, nil
+	}
+
+	matches := linkRegex.FindAllStringSubmatch(linkHeader, -1)
+	if matches == nil {
+		return nil, ResponseDecodingError{Message: fmt.Sprintf("could not parse link header: %q", linkHeader)}
+	}
+
+	for _, match := range matches {
+		link := match[0]
+		u, err := url.Parse(match[1])
+		if err != nil {
+			return nil, ResponseDecodingError{Message: fmt.Sprintf("could not parse link url: %v", err)}
+		}
+
+		q := u.Query()
+		pageInfo := q.Get("page_info")
+		if pageInfo == "" {
+			return nil, ResponseDecodingError{Message: fmt.Sprintf("link has no page_info: %q", link)}
+		}
+
+		options := &ListOptions{PageInfo: pageInfo, Fields: q.Get("fields")}
+		if limit := q.Get("limit"); limit != "" {
+			options.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				return nil, ResponseDecodingError{Message: fmt.Sprintf("link has invalid limit: %q", link)}
+			}
+		}
+
+		if match[2] == "next" {
+			pagination.NextPageOptions = options
+		} else {
+			pagination.PreviousPageOptions = options
+		}
+	}
+
+	return pagination, nil
+}
+
+// ListPage performs a GET request for a page of a cursor-paginated list,
+// saves the result in the given resource and returns the options for the
+// previous and next pages.
+func (c *Client) ListPage(path string, resource, options interface{}) (*Pagination, error) {
+	return c.ListPageWithContext(context.Background(), path, resource, options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (c *Client) ListPageWithContext(ctx context.Context, path string, resource, options interface{}) (*Pagination, error) {
+	headers, err := c.createAndDoGetHeaders(ctx, "GET", path, nil, options, resource)
+	if err != nil {
+		return nil, err
+	}
+	return parsePagination(headers.Get("Link"))
+}
+
+// listAll calls page with the given options and then with the options for
+// every next page until the last page has been fetched. Rate limited pages
+// are retried according to the client's RetryPolicy, or up to
+// DefaultRetryPolicy.MaxAttempts times here if the client doesn't retry, so
+// that the pages fetched so far aren't lost. Waiting stops when ctx is done.
+func (c *Client) listAll(ctx context.Context, options interface{}, page func(options interface{}) (*Pagination, error)) error {
+	policy := DefaultRetryPolicy
+	retried := c.Retry != nil && c.Retry.MaxAttempts >= 2
+
+	for attempt := 1; ; {
+		pagination, err := page(options)
+		if rateLimitErr, ok := err.(RateLimitError); ok && !retried && attempt < policy.MaxAttempts {
+			wait := time.Duration(rateLimitErr.RetryAfter) * time.Second
+			if wait == 0 {
+				wait = policy.backoff(attempt)
+			}
+			c.logf("goshopify: retrying rate limited page in %v (attempt %d of %d)", wait, attempt+1, policy.MaxAttempts)
+			if err := sleepContext(ctx, wait); err != nil {
+				return err
+			}
+			attempt++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if pagination.NextPageOptions == nil {
+			return nil
+		}
+		options = pagination.NextPageOptions
+		attempt = 1
+	}
+}
+
+// createAndDoGetHeaders is like CreateAndDoWithContext but also returns the
+// response headers.
+func (c *Client) createAndDoGetHeaders(ctx context.Context, method, path string, data, options, resource interface{}) (http.Header, error) {
+	req, err := c.NewRequestWithContext(ctx, method, path, data, options)
+	if err != nil {
+		return nil, err
+	}
+	return c.doGetHeaders(req, resource)
+}
@@ -0,0 +1,157 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+// linkResponder responds with the given body and Link header.
+func linkResponder(body, link string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, body)
+		if link != "" {
+			resp.Header.Set("Link", link)
+		}
+		return resp, nil
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	cases := []struct {
+		header   string
+		expected *Pagination
+		err      bool
+	}{
+		{
+			"",
+			&Pagination{},
+			false,
+		},
+		{
+			`<https://fooshop.myshopify.com/admin/api/2019-07/products.json?limit=50&page_info=abc>; rel="next"`,
+			&Pagination{NextPageOptions: &ListOptions{PageInfo: "abc", Limit: 50}},
+			false,
+		},
+		{
+			`<https://fooshop.myshopify.com/admin/products.json?page_info=abc&fields=id%2Ctitle>; rel="previous", <https://fooshop.myshopify.com/admin/products.json?page_info=def&limit=10>; rel="next"`,
+			&Pagination{
+				PreviousPageOptions: &ListOptions{PageInfo: "abc", Fields: "id,title"},
+				NextPageOptions:     &ListOptions{PageInfo: "def", Limit: 10},
+			},
+			false,
+		},
+		{
+			`<https://fooshop.myshopify.com/admin/products.json?limit=3&fields=id,title&page_info=abc>; rel="next"`,
+			&Pagination{NextPageOptions: &ListOptions{PageInfo: "abc", Limit: 3, Fields: "id,title"}},
+			false,
+		},
+		{
+			`<https://fooshop.myshopify.com/admin/products.json?fields=id,title&page_info=abc>;rel="previous",<https://fooshop.myshopify.com/admin/products.json?fields=id,title&page_info=def>; rel="next"`,
+			&Pagination{
+				PreviousPageOptions: &ListOptions{PageInfo: "abc", Fields: "id,title"},
+				NextPageOptions:     &ListOptions{PageInfo: "def", Fields: "id,title"},
+			},
+			false,
+		},
+		{`foo`, nil, true},
+		{`<https://fooshop.myshopify.com/admin/products.json?page_info=abc>; rel="self"`, nil, true},
+		{`<https://fooshop.myshopify.com/admin/products.json?limit=10>; rel="next"`, nil, true},
+		{`<https://fooshop.myshopify.com/admin/products.json?page_info=abc&limit=a>; rel="next"`, nil, true},
+	}
+
+	for _, c := range cases {
+		actual, err := parsePagination(c.header)
+		if (err != nil) != c.err {
+			t.Errorf("parsePagination(%q) err = %v, expected error %v", c.header, err, c.err)
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("parsePagination(%q) = %+v, expected %+v", c.header, actual, c.expected)
+		}
+	}
+}
+
+func TestListPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo.json",
+		linkResponder(`{"foo": "bar"}`, `<https://fooshop.myshopify.com/foo.json?page_info=abc>; rel="next"`))
+
+	resource := struct {
+		Foo string `json:"foo"`
+	}{}
+	pagination, err := client.ListPage("foo.json", &resource, nil)
+	if err != nil {
+		t.Fatalf("Client.ListPage returned error: %v", err)
+	}
+
+	if resource.Foo != "bar" {
+		t.Errorf("Client.ListPage resource = %+v, expected foo bar", resource)
+	}
+
+	expected := &Pagination{NextPageOptions: &ListOptions{PageInfo: "abc"}}
+	if !reflect.DeepEqual(pagination, expected) {
+		t.Errorf("Client.ListPage pagination = %+v, expected %+v", pagination, expected)
+	}
+}
+
+func TestListAllRateLimited(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/webhooks.json",
+		sequenceResponder(&calls,
+			linkResponder(`{"webhooks": [{"id":1}]}`, `<https://fooshop.myshopify.com/admin/webhooks.json?page_info=abc>; rel="next"`),
+		))
+	pageCalls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/webhooks.json?page_info=abc",
+		sequenceResponder(&pageCalls,
+			rateLimitResponder("0"),
+			linkResponder(`{"webhooks": [{"id":2}]}`, `<https://fooshop.myshopify.com/admin/webhooks.json?page_info=xyz>; rel="previous"`),
+		))
+
+	// Without a retry policy ListAll retries the page itself
+	webhooks, err := client.Webhook.ListAll(nil)
+	if err != nil {
+		t.Fatalf("Webhook.ListAll returned error: %v", err)
+	}
+
+	expected := []Webhook{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(webhooks, expected) {
+		t.Errorf("Webhook.ListAll returned %+v, expected %+v", webhooks, expected)
+	}
+
+	calls, pageCalls = 0, 0
+	client.Retry = &RetryPolicy{MaxAttempts: 2}
+	webhooks, err = client.Webhook.ListAll(nil)
+	if err != nil {
+		t.Fatalf("Webhook.ListAll returned error: %v", err)
+	}
+	if !reflect.DeepEqual(webhooks, expected) {
+		t.Errorf("Webhook.ListAll returned %+v, expected %+v", webhooks, expected)
+	}
+	if pageCalls != 2 {
+		t.Errorf("Webhook.ListAll fetched the rate limited page %d times, expected 2", pageCalls)
+	}
+}
+
+func TestListAllRateLimitedCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/webhooks.json",
+		rateLimitResponder("1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Webhook.ListAllWithContext(ctx, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Webhook.ListAllWithContext returned error %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -13,12 +14,24 @@ const productsResourceName = "products"
 // See: https://help.shopify.com/api/reference/product
 type ProductService interface {
 	List(interface{}) ([]Product, error)
+	ListPage(interface{}) ([]Product, *Pagination, error)
+	ListAll(interface{}) ([]Product, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Product, error)
 	Create(Product) (*Product, error)
 	Update(Product) (*Product, error)
 	Delete(int) error
 
+	ListWithContext(context.Context, interface{}) ([]Product, error)
+	ListPageWithContext(context.Context, interface{}) ([]Product, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Product, error)
+	Iterate(context.Context, interface{}) <-chan ProductResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Product, error)
+	CreateWithContext(context.Context, Product) (*Product, error)
+	UpdateWithContext(context.Context, Product) (*Product, error)
+	DeleteWithContext(context.Context, int) error
+
 	// MetafieldsService used for Product resource to communicate with Metafields resource
 	MetafieldsService
 }
@@ -71,83 +84,211 @@ type ProductsResource struct {
 	Products []Product `json:"products"`
 }
 
+// ProductResult is a product or an error from Iterate.
+type ProductResult struct {
+	Product Product
+	Err     error
+}
+
 // List products
 func (s *ProductServiceOp) List(options interface{}) ([]Product, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *ProductServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Product, error) {
 	path := fmt.Sprintf("%s.json", productsBasePath)
 	resource := new(ProductsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Products, err
 }
 
+// ListPage lists a page of products along with its pagination
+func (s *ProductServiceOp) ListPage(options interface{}) ([]Product, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *ProductServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Product, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", productsBasePath)
+	resource := new(ProductsResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Products, pagination, err
+}
+
+// ListAll lists all products, following the pagination from the given options
+func (s *ProductServiceOp) ListAll(options interface{}) ([]Product, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *ProductServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Product, error) {
+	var collector []Product
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		products, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, products...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all products over the returned channel, fetching the pages
+// lazily. The channel is closed after the last product or after a result
+// with an error. Cancel ctx to stop early.
+func (s *ProductServiceOp) Iterate(ctx context.Context, options interface{}) <-chan ProductResult {
+	results := make(chan ProductResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			products, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, product := range products {
+				select {
+				case results <- ProductResult{Product: product}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- ProductResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count products
 func (s *ProductServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *ProductServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", productsBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual product
 func (s *ProductServiceOp) Get(productID int, options interface{}) (*Product, error) {
+	return s.GetWithContext(context.Background(), productID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ProductServiceOp) GetWithContext(ctx context.Context, productID int, options interface{}) (*Product, error) {
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, productID)
 	resource := new(ProductResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Product, err
 }
 
 // Create a new product
 func (s *ProductServiceOp) Create(product Product) (*Product, error) {
+	return s.CreateWithContext(context.Background(), product)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *ProductServiceOp) CreateWithContext(ctx context.Context, product Product) (*Product, error) {
 	path := fmt.Sprintf("%s.json", productsBasePath)
 	wrappedData := ProductResource{Product: &product}
 	resource := new(ProductResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Product, err
 }
 
 // Update an existing product
 func (s *ProductServiceOp) Update(product Product) (*Product, error) {
+	return s.UpdateWithContext(context.Background(), product)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *ProductServiceOp) UpdateWithContext(ctx context.Context, product Product) (*Product, error) {
 	path := fmt.Sprintf("%s/%d.json", productsBasePath, product.ID)
 	wrappedData := ProductResource{Product: &product}
 	resource := new(ProductResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Product, err
 }
 
 // Delete an existing product
 func (s *ProductServiceOp) Delete(productID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", productsBasePath, productID))
+	return s.DeleteWithContext(context.Background(), productID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ProductServiceOp) DeleteWithContext(ctx context.Context, productID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", productsBasePath, productID))
 }
 
 // List metafields for a product
 func (s *ProductServiceOp) ListMetafields(productID int, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), productID, options)
+}
+
+// ListMetafieldsWithContext is like ListMetafields but uses ctx for the request.
+func (s *ProductServiceOp) ListMetafieldsWithContext(ctx context.Context, productID int, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.List(options)
+	return metafieldService.ListWithContext(ctx, options)
 }
 
 // Count metafields for a product
 func (s *ProductServiceOp) CountMetafields(productID int, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), productID, options)
+}
+
+// CountMetafieldsWithContext is like CountMetafields but uses ctx for the request.
+func (s *ProductServiceOp) CountMetafieldsWithContext(ctx context.Context, productID int, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Count(options)
+	return metafieldService.CountWithContext(ctx, options)
 }
 
 // Get individual metafield for a product
 func (s *ProductServiceOp) GetMetafield(productID int, metafieldID int, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), productID, metafieldID, options)
+}
+
+// GetMetafieldWithContext is like GetMetafield but uses ctx for the request.
+func (s *ProductServiceOp) GetMetafieldWithContext(ctx context.Context, productID int, metafieldID int, options interface{}) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Get(metafieldID, options)
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
 }
 
 // Create a new metafield for a product
 func (s *ProductServiceOp) CreateMetafield(productID int, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), productID, metafield)
+}
+
+// CreateMetafieldWithContext is like CreateMetafield but uses ctx for the request.
+func (s *ProductServiceOp) CreateMetafieldWithContext(ctx context.Context, productID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Create(metafield)
+	return metafieldService.CreateWithContext(ctx, metafield)
 }
 
 // Update an existing metafield for a product
 func (s *ProductServiceOp) UpdateMetafield(productID int, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), productID, metafield)
+}
+
+// UpdateMetafieldWithContext is like UpdateMetafield but uses ctx for the request.
+func (s *ProductServiceOp) UpdateMetafieldWithContext(ctx context.Context, productID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Update(metafield)
+	return metafieldService.UpdateWithContext(ctx, metafield)
 }
 
 // // Delete an existing metafield for a product
 func (s *ProductServiceOp) DeleteMetafield(productID int, metafieldID int) error {
+	return s.DeleteMetafieldWithContext(context.Background(), productID, metafieldID)
+}
+
+// DeleteMetafieldWithContext is like DeleteMetafield but uses ctx for the request.
+func (s *ProductServiceOp) DeleteMetafieldWithContext(ctx context.Context, productID int, metafieldID int) error {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-	return metafieldService.Delete(metafieldID)
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
 }
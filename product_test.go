@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
@@ -34,6 +35,73 @@ func TestProductList(t *testing.T) {
 	}
 }
 
+func TestProductListPage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/products.json?limit=2",
+		linkResponder(`{"products": [{"id":1},{"id":2}]}`,
+			`<https://fooshop.myshopify.com/admin/products.json?limit=2&page_info=abc>; rel="next"`))
+
+	products, pagination, err := client.Product.ListPage(ListOptions{Limit: 2})
+	if err != nil {
+		t.Errorf("Product.ListPage returned error: %v", err)
+	}
+
+	expected := []Product{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.ListPage returned %+v, expected %+v", products, expected)
+	}
+
+	expectedPagination := &Pagination{NextPageOptions: &ListOptions{PageInfo: "abc", Limit: 2}}
+	if !reflect.DeepEqual(pagination, expectedPagination) {
+		t.Errorf("Product.ListPage pagination %+v, expected %+v", pagination, expectedPagination)
+	}
+}
+
+func TestProductListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/products.json?limit=2",
+		linkResponder(`{"products": [{"id":1},{"id":2}]}`,
+			`<https://fooshop.myshopify.com/admin/products.json?limit=2&page_info=abc>; rel="next"`))
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/products.json?limit=2&page_info=abc",
+		linkResponder(`{"products": [{"id":3}]}`,
+			`<https://fooshop.myshopify.com/admin/products.json?limit=2&page_info=def>; rel="previous"`))
+
+	products, err := client.Product.ListAll(ListOptions{Limit: 2})
+	if err != nil {
+		t.Errorf("Product.ListAll returned error: %v", err)
+	}
+
+	expected := []Product{{ID: 1}, {ID: 2}, {ID: 3}}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.ListAll returned %+v, expected %+v", products, expected)
+	}
+}
+
+func TestProductListWithContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/products.json",
+		httpmock.NewStringResponder(200, `{"products": [{"id":1},{"id":2}]}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	products, err := client.Product.ListWithContext(ctx, nil)
+	if err != nil {
+		t.Errorf("Product.ListWithContext returned error: %v", err)
+	}
+
+	expected := []Product{{ID: 1}, {ID: 2}}
+	if !reflect.DeepEqual(products, expected) {
+		t.Errorf("Product.ListWithContext returned %+v, expected %+v", products, expected)
+	}
+}
+
 func TestProductCount(t *testing.T) {
 	setup()
 	defer teardown()
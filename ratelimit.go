@@ -0,0 +1,148 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const shopifyCallLimitHeader = "X-Shopify-Shop-Api-Call-Limit"
+
+const (
+	// DefaultCallLimitMax is the size of a standard shop's API call bucket.
+	DefaultCallLimitMax = 40
+
+	// DefaultCallLimitLeakRate is the number of calls per second that leak
+	// out of a standard shop's API call bucket.
+	DefaultCallLimitLeakRate = 2.0
+)
+
+// CallLimit is the state of a shop's API call bucket, as reported by Shopify
+// in the X-Shopify-Shop-Api-Call-Limit header, e.g. "32/40".
+type CallLimit struct {
+	Used int
+	Max  int
+}
+
+// Remaining returns the number of calls that can be made before the bucket
+// is full.
+func (l CallLimit) Remaining() int {
+	if l.Used >= l.Max {
+		return 0
+	}
+	return l.Max - l.Used
+}
+
+// parseCallLimit parses the call limit header of a response. The boolean is
+// false if the header is missing or malformed.
+func parseCallLimit(r *http.Response) (CallLimit, bool) {
+	parts := strings.Split(r.Header.Get(shopifyCallLimitHeader), "/")
+	if len(parts) != 2 {
+		return CallLimit{}, false
+	}
+	used, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return CallLimit{}, false
+	}
+	max, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || max <= 0 {
+		return CallLimit{}, false
+	}
+	return CallLimit{Used: used, Max: max}, true
+}
+
+// RateLimiter is a client-side model of Shopify's leaky bucket. It is updated
+// from the call limit header of every response and, when set on a Client,
+// blocks before sending a request that would overflow the bucket.
+//
+// A RateLimiter is safe for concurrent use and should be shared by every
+// Client talking to the same shop, since the bucket is per shop.
+type RateLimiter struct {
+	// LeakRate is the number of calls per second that leak out of the bucket.
+	LeakRate float64
+
+	mu      sync.Mutex
+	used    float64
+	max     int
+	updated time.Time
+}
+
+// NewRateLimiter returns a RateLimiter for a bucket of the given size and leak
+// rate. Use DefaultCallLimitMax and DefaultCallLimitLeakRate for standard
+// shops; the size is corrected from the responses in any case.
+func NewRateLimiter(max int, leakRate float64) *RateLimiter {
+	return &RateLimiter{LeakRate: leakRate, max: max}
+}
+
+// level returns the estimated number of calls in the bucket at the given time.
+// The caller must hold the lock.
+func (l *RateLimiter) level(now time.Time) float64 {
+	if l.updated.IsZero() || l.LeakRate <= 0 {
+		return l.used
+	}
+	level := l.used - now.Sub(l.updated).Seconds()*l.LeakRate
+	if level < 0 {
+		return 0
+	}
+	return level
+}
+
+// State returns the estimated current state of the bucket.
+func (l *RateLimiter) State() CallLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return CallLimit{Used: int(l.level(time.Now()) + 0.5), Max: l.max}
+}
+
+// Update sets the state of the bucket as reported by Shopify. The reported
+// count doesn't include the calls reserved by Wait that are still in flight,
+// so the bucket is never lowered below the local estimate.
+func (l *RateLimiter) Update(limit CallLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	used := float64(limit.Used)
+	if level := l.level(now); level > used {
+		used = level
+	}
+	l.used = used
+	l.max = limit.Max
+	l.updated = now
+}
+
+// Wait blocks until there is room in the bucket for another call and reserves
+// it, or until the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		level := l.level(now)
+		max := l.max
+		if max <= 0 {
+			max = DefaultCallLimitMax
+		}
+		if level+1 <= float64(max) || l.LeakRate <= 0 {
+			l.used = level + 1
+			l.updated = now
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((level + 1 - float64(max)) / l.LeakRate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// CallLimit returns the state of the shop's API call bucket as reported in
+// the last response received by the client.
+func (c *Client) CallLimit() CallLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.callLimit
+}
@@ -0,0 +1,185 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func callLimitResponder(limit string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{}`)
+		resp.Header.Add("X-Shopify-Shop-Api-Call-Limit", limit)
+		return resp, nil
+	}
+}
+
+func TestParseCallLimit(t *testing.T) {
+	cases := []struct {
+		header   string
+		expected CallLimit
+		ok       bool
+	}{
+		{"32/40", CallLimit{Used: 32, Max: 40}, true},
+		{"1/80", CallLimit{Used: 1, Max: 80}, true},
+		{"", CallLimit{}, false},
+		{"32", CallLimit{}, false},
+		{"a/40", CallLimit{}, false},
+		{"32/0", CallLimit{}, false},
+	}
+
+	for _, c := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("X-Shopify-Shop-Api-Call-Limit", c.header)
+		actual, ok := parseCallLimit(resp)
+		if actual != c.expected || ok != c.ok {
+			t.Errorf("parseCallLimit(%q) = %v, %v, expected %v, %v", c.header, actual, ok, c.expected, c.ok)
+		}
+	}
+}
+
+func TestCallLimitRemaining(t *testing.T) {
+	cases := []struct {
+		limit    CallLimit
+		expected int
+	}{
+		{CallLimit{Used: 32, Max: 40}, 8},
+		{CallLimit{Used: 40, Max: 40}, 0},
+		{CallLimit{}, 0},
+	}
+
+	for _, c := range cases {
+		if actual := c.limit.Remaining(); actual != c.expected {
+			t.Errorf("CallLimit%+v.Remaining() = %d, expected %d", c.limit, actual, c.expected)
+		}
+	}
+}
+
+func TestClientCallLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo",
+		callLimitResponder("32/40"))
+
+	err := client.Get("foo", nil, nil)
+	if err != nil {
+		t.Fatalf("Client.Get returned error: %v", err)
+	}
+
+	expected := CallLimit{Used: 32, Max: 40}
+	if actual := client.CallLimit(); actual != expected {
+		t.Errorf("Client.CallLimit() = %+v, expected %+v", actual, expected)
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Two clients for the same shop share one limiter
+	limiter := NewRateLimiter(DefaultCallLimitMax, 100)
+	client.RateLimiter = limiter
+	other := NewClient(app, "fooshop", "abcd")
+	other.RateLimiter = limiter
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo",
+		callLimitResponder("40/40"))
+
+	err := client.Get("foo", nil, nil)
+	if err != nil {
+		t.Fatalf("Client.Get returned error: %v", err)
+	}
+
+	if state := limiter.State(); state.Max != 40 || state.Used < 39 {
+		t.Errorf("RateLimiter.State() = %+v, expected a full bucket", state)
+	}
+
+	// The bucket is full, so the other client has to wait for a call to leak
+	start := time.Now()
+	err = other.Get("foo", nil, nil)
+	if err != nil {
+		t.Fatalf("Client.Get returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("Client.Get did not wait for the rate limiter, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := NewRateLimiter(2, 0.1)
+
+	// Two calls fit in the bucket
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("RateLimiter.Wait() returned error: %v", err)
+		}
+	}
+
+	expected := CallLimit{Used: 2, Max: 2}
+	if state := limiter.State(); state != expected {
+		t.Errorf("RateLimiter.State() = %+v, expected %+v", state, expected)
+	}
+
+	// The third has to wait ten seconds for room
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("RateLimiter.Wait() returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRateLimiterUpdateKeepsReservations(t *testing.T) {
+	limiter := NewRateLimiter(DefaultCallLimitMax, 0.001)
+
+	// Ten calls are in flight when the response of an earlier one arrives
+	for i := 0; i < 10; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("RateLimiter.Wait() returned error: %v", err)
+		}
+	}
+	limiter.Update(CallLimit{Used: 1, Max: 40})
+
+	if state := limiter.State(); state.Used != 10 || state.Max != 40 {
+		t.Errorf("RateLimiter.State() = %+v, expected the 10 reserved calls to be kept", state)
+	}
+
+	// A higher count from Shopify wins over the estimate
+	limiter.Update(CallLimit{Used: 30, Max: 40})
+	if state := limiter.State(); state.Used != 30 {
+		t.Errorf("RateLimiter.State() = %+v, expected 30 calls", state)
+	}
+}
+
+func TestClientRateLimiterSkipsGraphQL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	limiter := NewRateLimiter(2, 0.001)
+	client.RateLimiter = limiter
+	limiter.Update(CallLimit{Used: 2, Max: 2})
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/api/graphql.json",
+		httpmock.NewStringResponder(200, `{"data": {"shop": {"name": "Foo Shop"}}}`))
+
+	// GraphQL queries have their own bucket, so the full REST one is ignored
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.GraphQL.QueryWithContext(ctx, `{ shop { name } }`, nil, nil); err != nil {
+		t.Errorf("GraphQL.QueryWithContext returned error: %v", err)
+	}
+	if state := limiter.State(); state.Used != 2 {
+		t.Errorf("RateLimiter.State() = %+v, expected the GraphQL query not to be counted", state)
+	}
+
+	// REST requests still wait
+	req, _ := client.NewRequestWithContext(ctx, "GET", "foo", nil, nil)
+	if err := client.Do(req, nil); err != context.DeadlineExceeded {
+		t.Errorf("Client.Do returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
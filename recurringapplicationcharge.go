@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -20,6 +21,13 @@ type RecurringApplicationChargeService interface {
 	Activate(RecurringApplicationCharge) (*RecurringApplicationCharge, error)
 	Delete(int) error
 	Update(int, int) (*RecurringApplicationCharge, error)
+
+	CreateWithContext(context.Context, RecurringApplicationCharge) (*RecurringApplicationCharge, error)
+	GetWithContext(context.Context, int, interface{}) (*RecurringApplicationCharge, error)
+	ListWithContext(context.Context, interface{}) ([]RecurringApplicationCharge, error)
+	ActivateWithContext(context.Context, RecurringApplicationCharge) (*RecurringApplicationCharge, error)
+	DeleteWithContext(context.Context, int) error
+	UpdateWithContext(context.Context, int, int) (*RecurringApplicationCharge, error)
 }
 
 // RecurringApplicationChargeServiceOp handles communication with the
@@ -127,57 +135,92 @@ type RecurringApplicationChargesResource struct {
 // Create creates new recurring application charge.
 func (r *RecurringApplicationChargeServiceOp) Create(charge RecurringApplicationCharge) (
 	*RecurringApplicationCharge, error) {
+	return r.CreateWithContext(context.Background(), charge)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (r *RecurringApplicationChargeServiceOp) CreateWithContext(ctx context.Context, charge RecurringApplicationCharge) (
+	*RecurringApplicationCharge, error) {
 
 	path := fmt.Sprintf("%s.json", recurringApplicationChargesBasePath)
 	wrappedData := RecurringApplicationChargeResource{Charge: &charge}
 	resource := &RecurringApplicationChargeResource{}
-	err := r.client.Post(path, wrappedData, resource)
+	err := r.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Charge, err
 }
 
 // Get gets individual recurring application charge.
 func (r *RecurringApplicationChargeServiceOp) Get(chargeID int, options interface{}) (
 	*RecurringApplicationCharge, error) {
+	return r.GetWithContext(context.Background(), chargeID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (r *RecurringApplicationChargeServiceOp) GetWithContext(ctx context.Context, chargeID int, options interface{}) (
+	*RecurringApplicationCharge, error) {
 
 	path := fmt.Sprintf("%s/%d.json", recurringApplicationChargesBasePath, chargeID)
 	resource := &RecurringApplicationChargeResource{}
-	err := r.client.Get(path, resource, options)
+	err := r.client.GetWithContext(ctx, path, resource, options)
 	return resource.Charge, err
 }
 
 // List gets all recurring application charges.
 func (r *RecurringApplicationChargeServiceOp) List(options interface{}) (
 	[]RecurringApplicationCharge, error) {
+	return r.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (r *RecurringApplicationChargeServiceOp) ListWithContext(ctx context.Context, options interface{}) (
+	[]RecurringApplicationCharge, error) {
 
 	path := fmt.Sprintf("%s.json", recurringApplicationChargesBasePath)
 	resource := &RecurringApplicationChargesResource{}
-	err := r.client.Get(path, resource, options)
+	err := r.client.GetWithContext(ctx, path, resource, options)
 	return resource.Charges, err
 }
 
 // Activate activates recurring application charge.
 func (r *RecurringApplicationChargeServiceOp) Activate(charge RecurringApplicationCharge) (
 	*RecurringApplicationCharge, error) {
+	return r.ActivateWithContext(context.Background(), charge)
+}
+
+// ActivateWithContext is like Activate but uses ctx for the request.
+func (r *RecurringApplicationChargeServiceOp) ActivateWithContext(ctx context.Context, charge RecurringApplicationCharge) (
+	*RecurringApplicationCharge, error) {
 
 	path := fmt.Sprintf("%s/%d/activate.json", recurringApplicationChargesBasePath, charge.ID)
 	wrappedData := RecurringApplicationChargeResource{Charge: &charge}
 	resource := &RecurringApplicationChargeResource{}
-	err := r.client.Post(path, wrappedData, resource)
+	err := r.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Charge, err
 }
 
 // Delete deletes recurring application charge.
 func (r *RecurringApplicationChargeServiceOp) Delete(chargeID int) error {
-	return r.client.Delete(fmt.Sprintf("%s/%d.json", recurringApplicationChargesBasePath, chargeID))
+	return r.DeleteWithContext(context.Background(), chargeID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (r *RecurringApplicationChargeServiceOp) DeleteWithContext(ctx context.Context, chargeID int) error {
+	return r.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", recurringApplicationChargesBasePath, chargeID))
 }
 
 // Update updates recurring application charge.
 func (r *RecurringApplicationChargeServiceOp) Update(chargeID, newCappedAmount int) (
 	*RecurringApplicationCharge, error) {
+	return r.UpdateWithContext(context.Background(), chargeID, newCappedAmount)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (r *RecurringApplicationChargeServiceOp) UpdateWithContext(ctx context.Context, chargeID, newCappedAmount int) (
+	*RecurringApplicationCharge, error) {
 
 	path := fmt.Sprintf("%s/%d/customize.json?recurring_application_charge[capped_amount]=%d",
 		recurringApplicationChargesBasePath, chargeID, newCappedAmount)
 	resource := &RecurringApplicationChargeResource{}
-	err := r.client.Put(path, nil, resource)
+	err := r.client.PutWithContext(ctx, path, nil, resource)
 	return resource.Charge, err
 }
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -11,11 +12,23 @@ const redirectsBasePath = "admin/redirects"
 // See https://help.shopify.com/api/reference/online_store/redirect
 type RedirectService interface {
 	List(interface{}) ([]Redirect, error)
+	ListPage(interface{}) ([]Redirect, *Pagination, error)
+	ListAll(interface{}) ([]Redirect, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Redirect, error)
 	Create(Redirect) (*Redirect, error)
 	Update(Redirect) (*Redirect, error)
 	Delete(int) error
+
+	ListWithContext(context.Context, interface{}) ([]Redirect, error)
+	ListPageWithContext(context.Context, interface{}) ([]Redirect, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Redirect, error)
+	Iterate(context.Context, interface{}) <-chan RedirectResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Redirect, error)
+	CreateWithContext(context.Context, Redirect) (*Redirect, error)
+	UpdateWithContext(context.Context, Redirect) (*Redirect, error)
+	DeleteWithContext(context.Context, int) error
 }
 
 // RedirectServiceOp handles communication with the redirect related methods of the
@@ -41,47 +54,145 @@ type RedirectsResource struct {
 	Redirects []Redirect `json:"redirects"`
 }
 
+// RedirectResult is a redirect or an error from Iterate.
+type RedirectResult struct {
+	Redirect Redirect
+	Err      error
+}
+
 // List redirects
 func (s *RedirectServiceOp) List(options interface{}) ([]Redirect, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *RedirectServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Redirect, error) {
 	path := fmt.Sprintf("%s.json", redirectsBasePath)
 	resource := new(RedirectsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Redirects, err
 }
 
+// ListPage lists a page of redirects along with its pagination
+func (s *RedirectServiceOp) ListPage(options interface{}) ([]Redirect, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *RedirectServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Redirect, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", redirectsBasePath)
+	resource := new(RedirectsResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Redirects, pagination, err
+}
+
+// ListAll lists all redirects, following the pagination from the given options
+func (s *RedirectServiceOp) ListAll(options interface{}) ([]Redirect, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *RedirectServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Redirect, error) {
+	var collector []Redirect
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		redirects, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, redirects...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all redirects over the returned channel, fetching the pages
+// lazily. The channel is closed after the last redirect or after a result
+// with an error. Cancel ctx to stop early.
+func (s *RedirectServiceOp) Iterate(ctx context.Context, options interface{}) <-chan RedirectResult {
+	results := make(chan RedirectResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			redirects, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, redirect := range redirects {
+				select {
+				case results <- RedirectResult{Redirect: redirect}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- RedirectResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count redirects
 func (s *RedirectServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *RedirectServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", redirectsBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual redirect
 func (s *RedirectServiceOp) Get(redirectID int, options interface{}) (*Redirect, error) {
+	return s.GetWithContext(context.Background(), redirectID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *RedirectServiceOp) GetWithContext(ctx context.Context, redirectID int, options interface{}) (*Redirect, error) {
 	path := fmt.Sprintf("%s/%d.json", redirectsBasePath, redirectID)
 	resource := new(RedirectResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Redirect, err
 }
 
 // Create a new redirect
 func (s *RedirectServiceOp) Create(redirect Redirect) (*Redirect, error) {
+	return s.CreateWithContext(context.Background(), redirect)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *RedirectServiceOp) CreateWithContext(ctx context.Context, redirect Redirect) (*Redirect, error) {
 	path := fmt.Sprintf("%s.json", redirectsBasePath)
 	wrappedData := RedirectResource{Redirect: &redirect}
 	resource := new(RedirectResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Redirect, err
 }
 
 // Update an existing redirect
 func (s *RedirectServiceOp) Update(redirect Redirect) (*Redirect, error) {
+	return s.UpdateWithContext(context.Background(), redirect)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *RedirectServiceOp) UpdateWithContext(ctx context.Context, redirect Redirect) (*Redirect, error) {
 	path := fmt.Sprintf("%s/%d.json", redirectsBasePath, redirect.ID)
 	wrappedData := RedirectResource{Redirect: &redirect}
 	resource := new(RedirectResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Redirect, err
 }
 
 // Delete an existing redirect.
 func (s *RedirectServiceOp) Delete(redirectID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", redirectsBasePath, redirectID))
+	return s.DeleteWithContext(context.Background(), redirectID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *RedirectServiceOp) DeleteWithContext(ctx context.Context, redirectID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", redirectsBasePath, redirectID))
 }
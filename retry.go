@@ -0,0 +1,180 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how Client.Do retries requests that failed because
+// of rate limiting, server errors or network errors.
+//
+// Rate limited requests (429) are always safe to retry since Shopify rejects
+// them before doing any work, so they are retried for every method, waiting
+// for the duration given in the Retry-After header. Server (5xx) and network
+// errors are only retried for idempotent methods unless RetryNonIdempotent is
+// set, because a POST may have been applied even though it appeared to fail.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value below 2 disables retries.
+	MaxAttempts int
+
+	// MinBackoff and MaxBackoff bound the exponential backoff used between
+	// attempts when the response doesn't say how long to wait.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent allows POST requests to be retried after server and
+	// network errors.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a sensible policy for long running jobs such as bulk
+// syncs.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// backoff returns the time to wait before the given retry, using exponential
+// backoff with jitter. The first retry is attempt 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	if d <= 0 {
+		return 0
+	}
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	// Wait at least half of the backoff and a random amount of the rest so
+	// that concurrent clients don't retry in lockstep.
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// isIdempotent reports whether requests with the given method can be safely
+// repeated.
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the duration given in the Retry-After header of a
+// response, or 0 if it isn't set.
+func retryAfter(r *http.Response) time.Duration {
+	f, err := strconv.ParseFloat(r.Header.Get("Retry-After"), 64)
+	if err != nil || f <= 0 {
+		return 0
+	}
+	return time.Duration(f * float64(time.Second))
+}
+
+// sleepContext waits for the given duration or until the context is done,
+// whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// rewindBody resets the body of a request so that it can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody == nil {
+		return errBodyNotRewindable
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+var errBodyNotRewindable = errors.New("request body cannot be rewound for a retry")
+
+// drainBody discards the rest of a response body so that the underlying
+// connection can be reused.
+func drainBody(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, body)
+	body.Close()
+}
+
+// doWithRetry sends the request, retrying it according to the client's retry
+// policy. The returned response is the last one received, which the caller
+// must close.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.Retry
+	if policy == nil || policy.MaxAttempts < 2 {
+		return c.send(req)
+	}
+
+	ctx := req.Context()
+	safe := isIdempotent(req.Method) || policy.RetryNonIdempotent
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if err := rewindBody(req); err != nil {
+				return nil, err
+			}
+		}
+
+		last := attempt >= policy.MaxAttempts
+		resp, err := c.send(req)
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			// Don't retry when the caller gave up
+			if ctx.Err() != nil || last || !safe {
+				return nil, err
+			}
+			wait = policy.backoff(attempt)
+		case resp.StatusCode == http.StatusTooManyRequests:
+			if last {
+				return resp, nil
+			}
+			wait = retryAfter(resp)
+			if wait == 0 {
+				wait = policy.backoff(attempt)
+			}
+			drainBody(resp.Body)
+		case resp.StatusCode >= 500:
+			if last || !safe {
+				return resp, nil
+			}
+			wait = policy.backoff(attempt)
+			drainBody(resp.Body)
+		default:
+			return resp, nil
+		}
+
+		c.logf("goshopify: retrying %s %s in %v (attempt %d of %d)", req.Method, req.URL, wait, attempt+1, policy.MaxAttempts)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+// sequenceResponder returns the given responders in order, repeating the last
+// one, and counts the number of calls.
+func sequenceResponder(calls *int, responders ...httpmock.Responder) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		i := *calls
+		*calls++
+		if i >= len(responders) {
+			i = len(responders) - 1
+		}
+		return responders[i](req)
+	}
+}
+
+func rateLimitResponder(retryAfter string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(429, `{"errors":"Exceeded 2 calls per second for api client."}`)
+		resp.Header.Add("Retry-After", retryAfter)
+		return resp, nil
+	}
+}
+
+func TestDoRetry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Retry = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	cases := []struct {
+		method     string
+		responders []httpmock.Responder
+		calls      int
+		expected   error
+	}{
+		{
+			"GET",
+			[]httpmock.Responder{
+				rateLimitResponder("0.001"),
+				httpmock.NewStringResponder(200, `{}`),
+			},
+			2,
+			nil,
+		},
+		{
+			"GET",
+			[]httpmock.Responder{
+				httpmock.NewStringResponder(503, `{"errors": "unavailable"}`),
+				httpmock.NewErrorResponder(errors.New("connection reset")),
+				httpmock.NewStringResponder(200, `{}`),
+			},
+			3,
+			nil,
+		},
+		{
+			"GET",
+			[]httpmock.Responder{
+				httpmock.NewStringResponder(500, `{"errors": "oops"}`),
+			},
+			3,
+			ResponseError{Status: 500, Message: "oops"},
+		},
+		{
+			// A POST might have been applied, so server errors are not retried
+			"POST",
+			[]httpmock.Responder{
+				httpmock.NewStringResponder(500, `{"errors": "oops"}`),
+				httpmock.NewStringResponder(200, `{}`),
+			},
+			1,
+			ResponseError{Status: 500, Message: "oops"},
+		},
+		{
+			// But rate limited POSTs are never applied and can be retried
+			"POST",
+			[]httpmock.Responder{
+				rateLimitResponder("0"),
+				httpmock.NewStringResponder(200, `{}`),
+			},
+			2,
+			nil,
+		},
+		{
+			"GET",
+			[]httpmock.Responder{
+				httpmock.NewStringResponder(404, `{"error": "does not exist"}`),
+			},
+			1,
+			ResponseError{Status: 404, Message: "does not exist"},
+		},
+	}
+
+	for i, c := range cases {
+		calls := 0
+		httpmock.RegisterResponder(c.method, "https://fooshop.myshopify.com/foo",
+			sequenceResponder(&calls, c.responders...))
+
+		req, _ := client.NewRequest(c.method, "foo", map[string]string{"foo": "bar"}, nil)
+		err := client.Do(req, nil)
+
+		if !reflect.DeepEqual(err, c.expected) {
+			t.Errorf("Do() %d: expected error %#v, actual %#v", i, c.expected, err)
+		}
+		if calls != c.calls {
+			t.Errorf("Do() %d: expected %d calls, actual %d", i, c.calls, calls)
+		}
+	}
+}
+
+func TestDoRetryRewindsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Retry = &RetryPolicy{MaxAttempts: 2, RetryNonIdempotent: true}
+
+	var bodies []string
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/foo",
+		func(req *http.Request) (*http.Response, error) {
+			b, _ := ioutil.ReadAll(req.Body)
+			bodies = append(bodies, string(b))
+			if len(bodies) == 1 {
+				return httpmock.NewStringResponse(502, ``), nil
+			}
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	err := client.Post("foo", map[string]string{"foo": "bar"}, nil)
+	if err != nil {
+		t.Fatalf("Post() err = %v, expected nil", err)
+	}
+
+	expected := `{"foo":"bar"}`
+	if len(bodies) != 2 || bodies[0] != expected || bodies[1] != expected {
+		t.Errorf("Post() sent bodies %v, expected %s twice", bodies, expected)
+	}
+}
+
+func TestDoRetryContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Retry = &RetryPolicy{MaxAttempts: 5}
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/foo",
+		sequenceResponder(&calls, rateLimitResponder("60")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := client.GetWithContext(ctx, "foo", nil, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("GetWithContext() err = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if calls != 1 {
+		t.Errorf("GetWithContext() expected 1 call, actual %d", calls)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	cases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, c := range cases {
+		for i := 0; i < 20; i++ {
+			d := policy.backoff(c.attempt)
+			if d < c.min || d > c.max {
+				t.Errorf("RetryPolicy.backoff(%d) = %v, expected between %v and %v", c.attempt, d, c.min, c.max)
+			}
+		}
+	}
+}
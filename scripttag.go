@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -12,11 +13,23 @@ const scriptTagsBasePath = "admin/script_tags"
 // See: https://help.shopify.com/api/reference/scripttag
 type ScriptTagService interface {
 	List(interface{}) ([]ScriptTag, error)
+	ListPage(interface{}) ([]ScriptTag, *Pagination, error)
+	ListAll(interface{}) ([]ScriptTag, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*ScriptTag, error)
 	Create(ScriptTag) (*ScriptTag, error)
 	Update(ScriptTag) (*ScriptTag, error)
 	Delete(int) error
+
+	ListWithContext(context.Context, interface{}) ([]ScriptTag, error)
+	ListPageWithContext(context.Context, interface{}) ([]ScriptTag, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]ScriptTag, error)
+	Iterate(context.Context, interface{}) <-chan ScriptTagResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*ScriptTag, error)
+	CreateWithContext(context.Context, ScriptTag) (*ScriptTag, error)
+	UpdateWithContext(context.Context, ScriptTag) (*ScriptTag, error)
+	DeleteWithContext(context.Context, int) error
 }
 
 // ScriptTagServiceOp handles communication with the shop related methods of the
@@ -54,6 +67,12 @@ type ScriptTagsResource struct {
 	ScriptTags []ScriptTag `json:"script_tags"`
 }
 
+// ScriptTagResult is a script tag or an error from Iterate.
+type ScriptTagResult struct {
+	ScriptTag ScriptTag
+	Err       error
+}
+
 // ScriptTagResource represents the result from the
 // admin/script_tags/{#script_tag_id}.json endpoint.
 type ScriptTagResource struct {
@@ -62,45 +81,137 @@ type ScriptTagResource struct {
 
 // List script tags
 func (s *ScriptTagServiceOp) List(options interface{}) ([]ScriptTag, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *ScriptTagServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]ScriptTag, error) {
 	path := fmt.Sprintf("%s.json", scriptTagsBasePath)
 	resource := &ScriptTagsResource{}
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.ScriptTags, err
 }
 
+// ListPage lists a page of script tags along with its pagination
+func (s *ScriptTagServiceOp) ListPage(options interface{}) ([]ScriptTag, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *ScriptTagServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]ScriptTag, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", scriptTagsBasePath)
+	resource := &ScriptTagsResource{}
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.ScriptTags, pagination, err
+}
+
+// ListAll lists all script tags, following the pagination from the given options
+func (s *ScriptTagServiceOp) ListAll(options interface{}) ([]ScriptTag, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *ScriptTagServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]ScriptTag, error) {
+	var collector []ScriptTag
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		scriptTags, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, scriptTags...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all script tags over the returned channel, fetching the pages
+// lazily. The channel is closed after the last script tag or after a result
+// with an error. Cancel ctx to stop early.
+func (s *ScriptTagServiceOp) Iterate(ctx context.Context, options interface{}) <-chan ScriptTagResult {
+	results := make(chan ScriptTagResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			scriptTags, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, scriptTag := range scriptTags {
+				select {
+				case results <- ScriptTagResult{ScriptTag: scriptTag}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- ScriptTagResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count script tags
 func (s *ScriptTagServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *ScriptTagServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", scriptTagsBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual script tag
 func (s *ScriptTagServiceOp) Get(tagID int, options interface{}) (*ScriptTag, error) {
+	return s.GetWithContext(context.Background(), tagID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ScriptTagServiceOp) GetWithContext(ctx context.Context, tagID int, options interface{}) (*ScriptTag, error) {
 	path := fmt.Sprintf("%s/%d.json", scriptTagsBasePath, tagID)
 	resource := &ScriptTagResource{}
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.ScriptTag, err
 }
 
 // Create a new script tag
 func (s *ScriptTagServiceOp) Create(tag ScriptTag) (*ScriptTag, error) {
+	return s.CreateWithContext(context.Background(), tag)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *ScriptTagServiceOp) CreateWithContext(ctx context.Context, tag ScriptTag) (*ScriptTag, error) {
 	path := fmt.Sprintf("%s.json", scriptTagsBasePath)
 	wrappedData := ScriptTagResource{ScriptTag: &tag}
 	resource := &ScriptTagResource{}
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.ScriptTag, err
 }
 
 // Update an existing script tag
 func (s *ScriptTagServiceOp) Update(tag ScriptTag) (*ScriptTag, error) {
+	return s.UpdateWithContext(context.Background(), tag)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *ScriptTagServiceOp) UpdateWithContext(ctx context.Context, tag ScriptTag) (*ScriptTag, error) {
 	path := fmt.Sprintf("%s/%d.json", scriptTagsBasePath, tag.ID)
 	wrappedData := ScriptTagResource{ScriptTag: &tag}
 	resource := &ScriptTagResource{}
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.ScriptTag, err
 }
 
 // Delete an existing script tag
 func (s *ScriptTagServiceOp) Delete(tagID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", scriptTagsBasePath, tagID))
+	return s.DeleteWithContext(context.Background(), tagID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *ScriptTagServiceOp) DeleteWithContext(ctx context.Context, tagID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", scriptTagsBasePath, tagID))
 }
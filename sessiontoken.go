@@ -0,0 +1,185 @@
+package goshopify
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sessionTokenLeeway is the clock skew allowed when checking the exp and nbf
+// claims of a session token.
+const sessionTokenLeeway = 5 * time.Second
+
+// Errors returned when verifying a session token.
+var (
+	ErrSessionTokenMissing     = errors.New("goshopify: missing session token")
+	ErrSessionTokenMalformed   = errors.New("goshopify: malformed session token")
+	ErrSessionTokenSignature   = errors.New("goshopify: session token signature mismatch")
+	ErrSessionTokenExpired     = errors.New("goshopify: session token expired")
+	ErrSessionTokenNotYetValid = errors.New("goshopify: session token not valid yet")
+	ErrSessionTokenAudience    = errors.New("goshopify: session token is for another app")
+	ErrSessionTokenDestination = errors.New("goshopify: session token has an invalid destination")
+)
+
+// SessionToken holds the claims of a session token, the JWT that App Bridge
+// sends to the backend of an embedded app.
+// See: https://shopify.dev/apps/auth/oauth/session-tokens
+type SessionToken struct {
+	// Issuer is the admin of the shop, e.g.
+	// "https://fooshop.myshopify.com/admin".
+	Issuer string `json:"iss"`
+
+	// Destination is the shop, e.g. "https://fooshop.myshopify.com".
+	Destination string `json:"dest"`
+
+	// Audience is the API key of the app.
+	Audience string `json:"aud"`
+
+	// Subject is the ID of the staff member.
+	Subject string `json:"sub"`
+
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+	IssuedAt  int64  `json:"iat"`
+	ID        string `json:"jti"`
+	SessionID string `json:"sid"`
+
+	// Raw is the encoded token, as needed by ExchangeSessionToken.
+	Raw string `json:"-"`
+}
+
+// Shop returns the domain of the shop the token is for, e.g.
+// "fooshop.myshopify.com".
+func (t SessionToken) Shop() string {
+	u, err := url.Parse(t.Destination)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// UserID returns the ID of the staff member the token is for.
+func (t SessionToken) UserID() (int, error) {
+	return strconv.Atoi(t.Subject)
+}
+
+// VerifySessionToken verifies a session token signed with the app's secret
+// and returns its claims. It checks that the token is for the app, has not
+// expired and is for a shop. The returned error is one of the
+// ErrSessionToken errors.
+func (app App) VerifySessionToken(token string) (*SessionToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrSessionTokenMalformed
+	}
+
+	header := struct {
+		Alg string `json:"alg"`
+	}{}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, ErrSessionTokenMalformed
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrSessionTokenMalformed
+	}
+	if !hmac.Equal(signature, app.sign([]byte(parts[0]+"."+parts[1]))) {
+		return nil, ErrSessionTokenSignature
+	}
+
+	claims := &SessionToken{Raw: token}
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(sessionTokenLeeway)) {
+		return nil, ErrSessionTokenExpired
+	}
+	if now.Before(time.Unix(claims.NotBefore, 0).Add(-sessionTokenLeeway)) {
+		return nil, ErrSessionTokenNotYetValid
+	}
+	if claims.Audience != app.ApiKey {
+		return nil, ErrSessionTokenAudience
+	}
+
+	shop := claims.Shop()
+	if ValidateShopDomain(shop, app.ShopDomainSuffixes...) != nil || claims.Destination != "https://"+shop {
+		return nil, ErrSessionTokenDestination
+	}
+	if issuer, err := url.Parse(claims.Issuer); err != nil || issuer.Host != shop {
+		return nil, ErrSessionTokenDestination
+	}
+	return claims, nil
+}
+
+// VerifySessionTokenRequest verifies the session token in the Authorization
+// header of a request, as sent by App Bridge with "Bearer <token>".
+func (app App) VerifySessionTokenRequest(r *http.Request) (*SessionToken, error) {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) || len(header) == len(prefix) {
+		return nil, ErrSessionTokenMissing
+	}
+	return app.VerifySessionToken(header[len(prefix):])
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return ErrSessionTokenMalformed
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return ErrSessionTokenMalformed
+	}
+	return nil
+}
+
+// AccessTokenType is the type of access token requested by a token exchange.
+type AccessTokenType string
+
+// Access token types
+const (
+	OfflineAccessTokenType AccessTokenType = "urn:shopify:params:oauth:token-type:offline-access-token"
+	OnlineAccessTokenType  AccessTokenType = "urn:shopify:params:oauth:token-type:online-access-token"
+)
+
+// ExchangeSessionToken exchanges a verified session token for an access
+// token of the shop it is for, without redirecting through OAuth. Online
+// tokens act for the staff member of the session token.
+// See: https://shopify.dev/apps/auth/get-access-tokens/token-exchange
+func (app App) ExchangeSessionToken(token *SessionToken, tokenType AccessTokenType) (*AccessToken, error) {
+	return app.ExchangeSessionTokenWithContext(context.Background(), token, tokenType)
+}
+
+// ExchangeSessionTokenWithContext is like ExchangeSessionToken but uses ctx
+// for the request.
+func (app App) ExchangeSessionTokenWithContext(ctx context.Context, token *SessionToken, tokenType AccessTokenType) (*AccessToken, error) {
+	data := struct {
+		ClientId           string          `json:"client_id"`
+		ClientSecret       string          `json:"client_secret"`
+		GrantType          string          `json:"grant_type"`
+		SubjectToken       string          `json:"subject_token"`
+		SubjectTokenType   string          `json:"subject_token_type"`
+		RequestedTokenType AccessTokenType `json:"requested_token_type"`
+	}{
+		ClientId:           app.ApiKey,
+		ClientSecret:       app.ApiSecret,
+		GrantType:          "urn:ietf:params:oauth:grant-type:token-exchange",
+		SubjectToken:       token.Raw,
+		SubjectTokenType:   "urn:ietf:params:oauth:token-type:id_token",
+		RequestedTokenType: tokenType,
+	}
+	return app.requestAccessToken(ctx, token.Shop(), data)
+}
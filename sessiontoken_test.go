@@ -0,0 +1,161 @@
+package goshopify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/jarcoal/httpmock.v1"
+)
+
+// newSessionToken returns a JWT with the claims signed with the secret.
+func newSessionToken(secret, alg string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	token := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(token))
+	return token + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func sessionTokenClaims() map[string]interface{} {
+	now := time.Now().Unix()
+	return map[string]interface{}{
+		"iss":  "https://fooshop.myshopify.com/admin",
+		"dest": "https://fooshop.myshopify.com",
+		"aud":  "apikey",
+		"sub":  "42",
+		"exp":  now + 60,
+		"nbf":  now,
+		"iat":  now,
+		"jti":  "f8912129-1af6-4cad-9ca3-76b0f7621087",
+		"sid":  "aaea182f2732d44c23057c0fea584021a4485b2bd25d3eb7fd349313ad24c685",
+	}
+}
+
+func TestAppVerifySessionToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	claims := sessionTokenClaims()
+	raw := newSessionToken("hush", "HS256", claims)
+	token, err := app.VerifySessionToken(raw)
+	if err != nil {
+		t.Fatalf("App.VerifySessionToken returned error: %v", err)
+	}
+
+	expected := &SessionToken{
+		Issuer:      "https://fooshop.myshopify.com/admin",
+		Destination: "https://fooshop.myshopify.com",
+		Audience:    "apikey",
+		Subject:     "42",
+		ExpiresAt:   claims["exp"].(int64),
+		NotBefore:   claims["nbf"].(int64),
+		IssuedAt:    claims["iat"].(int64),
+		ID:          "f8912129-1af6-4cad-9ca3-76b0f7621087",
+		SessionID:   "aaea182f2732d44c23057c0fea584021a4485b2bd25d3eb7fd349313ad24c685",
+		Raw:         raw,
+	}
+	if !reflect.DeepEqual(token, expected) {
+		t.Errorf("App.VerifySessionToken returned %+v, expected %+v", token, expected)
+	}
+	if shop := token.Shop(); shop != "fooshop.myshopify.com" {
+		t.Errorf("SessionToken.Shop returned %q, expected fooshop.myshopify.com", shop)
+	}
+	if id, err := token.UserID(); id != 42 || err != nil {
+		t.Errorf("SessionToken.UserID returned %d, %v, expected 42", id, err)
+	}
+}
+
+func TestAppVerifySessionTokenErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := sessionTokenClaims()
+		claims[key] = value
+		return claims
+	}
+	now := time.Now().Unix()
+
+	cases := []struct {
+		description string
+		token       string
+		expected    error
+	}{
+		{"empty", "", ErrSessionTokenMalformed},
+		{"two parts", "e30.e30", ErrSessionTokenMalformed},
+		{"invalid header", "!.e30.e30", ErrSessionTokenMalformed},
+		{"other algorithm", newSessionToken("hush", "none", sessionTokenClaims()), ErrSessionTokenMalformed},
+		{"wrong secret", newSessionToken("wrong", "HS256", sessionTokenClaims()), ErrSessionTokenSignature},
+		{"expired", newSessionToken("hush", "HS256", with("exp", now-60)), ErrSessionTokenExpired},
+		{"not yet valid", newSessionToken("hush", "HS256", with("nbf", now+60)), ErrSessionTokenNotYetValid},
+		{"other app", newSessionToken("hush", "HS256", with("aud", "otherkey")), ErrSessionTokenAudience},
+		{"other destination", newSessionToken("hush", "HS256", with("dest", "https://evil.com")), ErrSessionTokenDestination},
+		{"destination with path", newSessionToken("hush", "HS256", with("dest", "https://fooshop.myshopify.com/foo")), ErrSessionTokenDestination},
+		{"issuer of other shop", newSessionToken("hush", "HS256", with("iss", "https://barshop.myshopify.com/admin")), ErrSessionTokenDestination},
+	}
+	for _, c := range cases {
+		if _, err := app.VerifySessionToken(c.token); err != c.expected {
+			t.Errorf("App.VerifySessionToken returned %v for %s token, expected %v", err, c.description, c.expected)
+		}
+	}
+}
+
+func TestAppVerifySessionTokenRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	r := httptest.NewRequest("GET", "/api/products", nil)
+	if _, err := app.VerifySessionTokenRequest(r); err != ErrSessionTokenMissing {
+		t.Errorf("App.VerifySessionTokenRequest returned %v, expected %v", err, ErrSessionTokenMissing)
+	}
+
+	r.Header.Set("Authorization", "Bearer "+newSessionToken("hush", "HS256", sessionTokenClaims()))
+	if token, err := app.VerifySessionTokenRequest(r); err != nil || token.Subject != "42" {
+		t.Errorf("App.VerifySessionTokenRequest returned %+v, %v, expected the token of user 42", token, err)
+	}
+}
+
+func TestAppExchangeSessionToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	raw := newSessionToken("hush", "HS256", sessionTokenClaims())
+	var body map[string]string
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/oauth/access_token",
+		func(req *http.Request) (*http.Response, error) {
+			json.NewDecoder(req.Body).Decode(&body)
+			return httpmock.NewStringResponse(200, `{"access_token":"footoken","scope":"read_products","expires_in":86399,"associated_user":{"id":42}}`), nil
+		})
+
+	session, err := app.VerifySessionToken(raw)
+	if err != nil {
+		t.Fatalf("App.VerifySessionToken returned error: %v", err)
+	}
+	token, err := app.ExchangeSessionToken(session, OnlineAccessTokenType)
+	if err != nil {
+		t.Fatalf("App.ExchangeSessionToken returned error: %v", err)
+	}
+
+	expectedBody := map[string]string{
+		"client_id":            "apikey",
+		"client_secret":        "hush",
+		"grant_type":           "urn:ietf:params:oauth:grant-type:token-exchange",
+		"subject_token":        raw,
+		"subject_token_type":   "urn:ietf:params:oauth:token-type:id_token",
+		"requested_token_type": "urn:shopify:params:oauth:token-type:online-access-token",
+	}
+	if !reflect.DeepEqual(body, expectedBody) {
+		t.Errorf("App.ExchangeSessionToken sent %v, expected %v", body, expectedBody)
+	}
+	if token.Token != "footoken" || !token.Online() || token.ExpiresAt.IsZero() {
+		t.Errorf("App.ExchangeSessionToken returned %+v, expected an online token", token)
+	}
+}
@@ -1,12 +1,17 @@
 package goshopify
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // ShopService is an interface for interfacing with the shop endpoint of the
 // Shopify API.
 // See: https://help.shopify.com/api/reference/shop
 type ShopService interface {
 	Get(options interface{}) (*Shop, error)
+
+	GetWithContext(ctx context.Context, options interface{}) (*Shop, error)
 }
 
 // ShopServiceOp handles communication with the shop related methods of the
@@ -66,7 +71,12 @@ type ShopResource struct {
 
 // Get shop
 func (s *ShopServiceOp) Get(options interface{}) (*Shop, error) {
+	return s.GetWithContext(context.Background(), options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *ShopServiceOp) GetWithContext(ctx context.Context, options interface{}) (*Shop, error) {
 	resource := new(ShopResource)
-	err := s.client.Get("admin/shop.json", resource, options)
+	err := s.client.GetWithContext(ctx, "admin/shop.json", resource, options)
 	return resource.Shop, err
 }
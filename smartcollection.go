@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -13,12 +14,24 @@ const smartCollectionsResourceName = "collections"
 // See https://help.shopify.com/api/reference/smartcollection
 type SmartCollectionService interface {
 	List(interface{}) ([]SmartCollection, error)
+	ListPage(interface{}) ([]SmartCollection, *Pagination, error)
+	ListAll(interface{}) ([]SmartCollection, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*SmartCollection, error)
 	Create(SmartCollection) (*SmartCollection, error)
 	Update(SmartCollection) (*SmartCollection, error)
 	Delete(int) error
 
+	ListWithContext(context.Context, interface{}) ([]SmartCollection, error)
+	ListPageWithContext(context.Context, interface{}) ([]SmartCollection, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]SmartCollection, error)
+	Iterate(context.Context, interface{}) <-chan SmartCollectionResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*SmartCollection, error)
+	CreateWithContext(context.Context, SmartCollection) (*SmartCollection, error)
+	UpdateWithContext(context.Context, SmartCollection) (*SmartCollection, error)
+	DeleteWithContext(context.Context, int) error
+
 	// MetafieldsService used for SmartCollection resource to communicate with Metafields resource
 	MetafieldsService
 }
@@ -63,84 +76,212 @@ type SmartCollectionsResource struct {
 	Collections []SmartCollection `json:"smart_collections"`
 }
 
+// SmartCollectionResult is a smart collection or an error from Iterate.
+type SmartCollectionResult struct {
+	SmartCollection SmartCollection
+	Err             error
+}
+
 // List smart collections
 func (s *SmartCollectionServiceOp) List(options interface{}) ([]SmartCollection, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *SmartCollectionServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]SmartCollection, error) {
 	path := fmt.Sprintf("%s.json", smartCollectionsBasePath)
 	resource := new(SmartCollectionsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Collections, err
 }
 
+// ListPage lists a page of smart collections along with its pagination
+func (s *SmartCollectionServiceOp) ListPage(options interface{}) ([]SmartCollection, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *SmartCollectionServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]SmartCollection, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", smartCollectionsBasePath)
+	resource := new(SmartCollectionsResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Collections, pagination, err
+}
+
+// ListAll lists all smart collections, following the pagination from the given options
+func (s *SmartCollectionServiceOp) ListAll(options interface{}) ([]SmartCollection, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *SmartCollectionServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]SmartCollection, error) {
+	var collector []SmartCollection
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		smartCollections, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, smartCollections...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all smart collections over the returned channel, fetching the pages
+// lazily. The channel is closed after the last smart collection or after a result
+// with an error. Cancel ctx to stop early.
+func (s *SmartCollectionServiceOp) Iterate(ctx context.Context, options interface{}) <-chan SmartCollectionResult {
+	results := make(chan SmartCollectionResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			smartCollections, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, smartCollection := range smartCollections {
+				select {
+				case results <- SmartCollectionResult{SmartCollection: smartCollection}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- SmartCollectionResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count smart collections
 func (s *SmartCollectionServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *SmartCollectionServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", smartCollectionsBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual smart collection
 func (s *SmartCollectionServiceOp) Get(collectionID int, options interface{}) (*SmartCollection, error) {
+	return s.GetWithContext(context.Background(), collectionID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *SmartCollectionServiceOp) GetWithContext(ctx context.Context, collectionID int, options interface{}) (*SmartCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionID)
 	resource := new(SmartCollectionResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Collection, err
 }
 
 // Create a new smart collection
 // See Image for the details of the Image creation for a collection.
 func (s *SmartCollectionServiceOp) Create(collection SmartCollection) (*SmartCollection, error) {
+	return s.CreateWithContext(context.Background(), collection)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *SmartCollectionServiceOp) CreateWithContext(ctx context.Context, collection SmartCollection) (*SmartCollection, error) {
 	path := fmt.Sprintf("%s.json", smartCollectionsBasePath)
 	wrappedData := SmartCollectionResource{Collection: &collection}
 	resource := new(SmartCollectionResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Collection, err
 }
 
 // Update an existing smart collection
 func (s *SmartCollectionServiceOp) Update(collection SmartCollection) (*SmartCollection, error) {
+	return s.UpdateWithContext(context.Background(), collection)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *SmartCollectionServiceOp) UpdateWithContext(ctx context.Context, collection SmartCollection) (*SmartCollection, error) {
 	path := fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collection.ID)
 	wrappedData := SmartCollectionResource{Collection: &collection}
 	resource := new(SmartCollectionResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Collection, err
 }
 
 // Delete an existing smart collection.
 func (s *SmartCollectionServiceOp) Delete(collectionID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionID))
+	return s.DeleteWithContext(context.Background(), collectionID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *SmartCollectionServiceOp) DeleteWithContext(ctx context.Context, collectionID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", smartCollectionsBasePath, collectionID))
 }
 
 // List metafields for a smart collection
 func (s *SmartCollectionServiceOp) ListMetafields(smartCollectionID int, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), smartCollectionID, options)
+}
+
+// ListMetafieldsWithContext is like ListMetafields but uses ctx for the request.
+func (s *SmartCollectionServiceOp) ListMetafieldsWithContext(ctx context.Context, smartCollectionID int, options interface{}) ([]Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceID: smartCollectionID}
-	return metafieldService.List(options)
+	return metafieldService.ListWithContext(ctx, options)
 }
 
 // Count metafields for a smart collection
 func (s *SmartCollectionServiceOp) CountMetafields(smartCollectionID int, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), smartCollectionID, options)
+}
+
+// CountMetafieldsWithContext is like CountMetafields but uses ctx for the request.
+func (s *SmartCollectionServiceOp) CountMetafieldsWithContext(ctx context.Context, smartCollectionID int, options interface{}) (int, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceID: smartCollectionID}
-	return metafieldService.Count(options)
+	return metafieldService.CountWithContext(ctx, options)
 }
 
 // Get individual metafield for a smart collection
 func (s *SmartCollectionServiceOp) GetMetafield(smartCollectionID int, metafieldID int, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), smartCollectionID, metafieldID, options)
+}
+
+// GetMetafieldWithContext is like GetMetafield but uses ctx for the request.
+func (s *SmartCollectionServiceOp) GetMetafieldWithContext(ctx context.Context, smartCollectionID int, metafieldID int, options interface{}) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceID: smartCollectionID}
-	return metafieldService.Get(metafieldID, options)
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
 }
 
 // Create a new metafield for a smart collection
 func (s *SmartCollectionServiceOp) CreateMetafield(smartCollectionID int, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), smartCollectionID, metafield)
+}
+
+// CreateMetafieldWithContext is like CreateMetafield but uses ctx for the request.
+func (s *SmartCollectionServiceOp) CreateMetafieldWithContext(ctx context.Context, smartCollectionID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceID: smartCollectionID}
-	return metafieldService.Create(metafield)
+	return metafieldService.CreateWithContext(ctx, metafield)
 }
 
 // Update an existing metafield for a smart collection
 func (s *SmartCollectionServiceOp) UpdateMetafield(smartCollectionID int, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), smartCollectionID, metafield)
+}
+
+// UpdateMetafieldWithContext is like UpdateMetafield but uses ctx for the request.
+func (s *SmartCollectionServiceOp) UpdateMetafieldWithContext(ctx context.Context, smartCollectionID int, metafield Metafield) (*Metafield, error) {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceID: smartCollectionID}
-	return metafieldService.Update(metafield)
+	return metafieldService.UpdateWithContext(ctx, metafield)
 }
 
 // // Delete an existing metafield for a smart collection
 func (s *SmartCollectionServiceOp) DeleteMetafield(smartCollectionID int, metafieldID int) error {
+	return s.DeleteMetafieldWithContext(context.Background(), smartCollectionID, metafieldID)
+}
+
+// DeleteMetafieldWithContext is like DeleteMetafield but uses ctx for the request.
+func (s *SmartCollectionServiceOp) DeleteMetafieldWithContext(ctx context.Context, smartCollectionID int, metafieldID int) error {
 	metafieldService := &MetafieldServiceOp{client: s.client, resource: smartCollectionsResourceName, resourceID: smartCollectionID}
-	return metafieldService.Delete(metafieldID)
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
 }
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -18,6 +19,8 @@ type ThemeListOptions struct {
 // See: https://help.shopify.com/api/reference/theme
 type ThemeService interface {
 	List(interface{}) ([]Theme, error)
+
+	ListWithContext(context.Context, interface{}) ([]Theme, error)
 }
 
 // ThemeServiceOp handles communication with the theme related methods of
@@ -45,8 +48,13 @@ type ThemesResource struct {
 
 // List all themes
 func (s *ThemeServiceOp) List(options interface{}) ([]Theme, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *ThemeServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Theme, error) {
 	path := fmt.Sprintf("%s.json", themesBasePath)
 	resource := new(ThemesResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Themes, err
 }
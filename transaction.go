@@ -1,6 +1,9 @@
 package goshopify
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // TransactionService is an interface for interfacing with the transactions endpoints of
 // the Shopify API.
@@ -10,6 +13,11 @@ type TransactionService interface {
 	Count(int, interface{}) (int, error)
 	Get(int, int, interface{}) (*Transaction, error)
 	Create(int, Transaction) (*Transaction, error)
+
+	ListWithContext(context.Context, int, interface{}) ([]Transaction, error)
+	CountWithContext(context.Context, int, interface{}) (int, error)
+	GetWithContext(context.Context, int, int, interface{}) (*Transaction, error)
+	CreateWithContext(context.Context, int, Transaction) (*Transaction, error)
 }
 
 // TransactionServiceOp handles communication with the transaction related methods of the
@@ -30,31 +38,51 @@ type TransactionsResource struct {
 
 // List transactions
 func (s *TransactionServiceOp) List(orderID int, options interface{}) ([]Transaction, error) {
+	return s.ListWithContext(context.Background(), orderID, options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *TransactionServiceOp) ListWithContext(ctx context.Context, orderID int, options interface{}) ([]Transaction, error) {
 	path := fmt.Sprintf("%s/%d/transactions.json", ordersBasePath, orderID)
 	resource := new(TransactionsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Transactions, err
 }
 
 // Count transactions
 func (s *TransactionServiceOp) Count(orderID int, options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), orderID, options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *TransactionServiceOp) CountWithContext(ctx context.Context, orderID int, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/%d/transactions/count.json", ordersBasePath, orderID)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual transaction
 func (s *TransactionServiceOp) Get(orderID int, transactionID int, options interface{}) (*Transaction, error) {
+	return s.GetWithContext(context.Background(), orderID, transactionID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *TransactionServiceOp) GetWithContext(ctx context.Context, orderID int, transactionID int, options interface{}) (*Transaction, error) {
 	path := fmt.Sprintf("%s/%d/transactions/%d.json", ordersBasePath, orderID, transactionID)
 	resource := new(TransactionResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Transaction, err
 }
 
 // Create a new transaction
 func (s *TransactionServiceOp) Create(orderID int, transaction Transaction) (*Transaction, error) {
+	return s.CreateWithContext(context.Background(), orderID, transaction)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *TransactionServiceOp) CreateWithContext(ctx context.Context, orderID int, transaction Transaction) (*Transaction, error) {
 	path := fmt.Sprintf("%s/%d/transactions.json", ordersBasePath, orderID)
 	wrappedData := TransactionResource{Transaction: &transaction}
 	resource := new(TransactionResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Transaction, err
 }
@@ -2,10 +2,69 @@ package goshopify
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// DefaultShopDomainSuffixes are the domains shops may be under if
+// App.ShopDomainSuffixes is empty.
+var DefaultShopDomainSuffixes = []string{"myshopify.com"}
+
+var shopNameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// ShopDomainError occurs when a shop is not a valid shop domain.
+type ShopDomainError struct {
+	Shop string
+}
+
+func (e ShopDomainError) Error() string {
+	return fmt.Sprintf("goshopify: invalid shop domain %q", e.Shop)
+}
+
+// ValidateShopDomain checks that the shop is a domain like
+// "theshop.myshopify.com", i.e. a single hostname label under one of the
+// suffixes, or DefaultShopDomainSuffixes if none are given. It returns a
+// ShopDomainError otherwise, e.g. for "evil.com/x" or
+// "theshop.myshopify.com.evil.com", which must not be used to build the
+// URLs of requests or redirects.
+func ValidateShopDomain(shop string, suffixes ...string) error {
+	if len(suffixes) == 0 {
+		suffixes = DefaultShopDomainSuffixes
+	}
+	for _, suffix := range suffixes {
+		suffix = "." + strings.TrimPrefix(suffix, ".")
+		if len(shop) > len(suffix) && strings.EqualFold(shop[len(shop)-len(suffix):], suffix) &&
+			shopNameRegex.MatchString(shop[:len(shop)-len(suffix)]) {
+			return nil
+		}
+	}
+	return ShopDomainError{shop}
+}
+
+// ShopDomain returns the lowercase domain of a shop given by its name, e.g.
+// "theshop" for "theshop.myshopify.com", or by its domain. Names are put under
+// the first of the ShopDomainSuffixes of the app, or DefaultShopDomainSuffixes
+// if it has none. A ShopDomainError is returned if the domain is not under
+// one of the suffixes, see ValidateShopDomain.
+func (app App) ShopDomain(shopName string) (string, error) {
+	domain := strings.ToLower(strings.Trim(strings.TrimSpace(shopName), "."))
+	if domain != "" && !strings.Contains(domain, ".") {
+		suffixes := app.ShopDomainSuffixes
+		if len(suffixes) == 0 {
+			suffixes = DefaultShopDomainSuffixes
+		}
+		domain += "." + strings.TrimPrefix(suffixes[0], ".")
+	}
+	if err := ValidateShopDomain(domain, app.ShopDomainSuffixes...); err != nil {
+		return "", ShopDomainError{shopName}
+	}
+	return domain, nil
+}
+
 // Return the full shop name, including .myshopify.com
+//
+// Deprecated: ShopFullName does not validate the name, so the result must
+// not be used to build URLs. Use App.ShopDomain instead.
 func ShopFullName(name string) string {
 	name = strings.TrimSpace(name)
 	name = strings.Trim(name, ".")
@@ -24,6 +83,9 @@ func ShopShortName(name string) string {
 }
 
 // Return the Shop's base url.
+//
+// Deprecated: ShopBaseUrl does not validate the name and can return the URL
+// of any host, e.g. for "evil.com/x". Use App.ShopDomain instead.
 func ShopBaseUrl(name string) string {
 	name = ShopFullName(name)
 	return fmt.Sprintf("https://%s", name)
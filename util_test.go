@@ -98,3 +98,65 @@ func TestFulfillmentPathPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateShopDomain(t *testing.T) {
+	cases := []struct {
+		shop     string
+		suffixes []string
+		expected bool
+	}{
+		{"myshop.myshopify.com", nil, true},
+		{"my-shop2.MyShopify.com", nil, true},
+		{"myshop", nil, false},
+		{"", nil, false},
+		{".myshopify.com", nil, false},
+		{"-myshop.myshopify.com", nil, false},
+		{"myshop-.myshopify.com", nil, false},
+		{"my.shop.myshopify.com", nil, false},
+		{"evil.com/x.myshopify.com", nil, false},
+		{"myshop.myshopify.com.evil.com", nil, false},
+		{"myshop.myshopify.com/admin", nil, false},
+		{"evil.com?.myshopify.com", nil, false},
+		{"myshop.myshopify.io", nil, false},
+		{"myshop.myshopify.io", []string{"myshopify.com", ".myshopify.io"}, true},
+		{"myshop.myshopify.com", []string{"myshopify.io"}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateShopDomain(c.shop, c.suffixes...)
+		if (err == nil) != c.expected {
+			t.Errorf("ValidateShopDomain(%q, %v) returned %v, expected valid %v", c.shop, c.suffixes, err, c.expected)
+		}
+		if err != nil && err != (ShopDomainError{c.shop}) {
+			t.Errorf("ValidateShopDomain(%q, %v) returned %v, expected a ShopDomainError", c.shop, c.suffixes, err)
+		}
+	}
+}
+
+func TestAppShopDomain(t *testing.T) {
+	cases := []struct {
+		app      App
+		in       string
+		expected string
+	}{
+		{App{}, "myshop", "myshop.myshopify.com"},
+		{App{}, " myshop.myshopify.com. ", "myshop.myshopify.com"},
+		{App{}, "MyShop.MyShopify.com", "myshop.myshopify.com"},
+		{App{}, "evil.com/x", ""},
+		{App{}, "myshop.myshopify.com.evil.com", ""},
+		{App{}, "", ""},
+		{App{ShopDomainSuffixes: []string{"myshopify.io"}}, "myshop.myshopify.io", "myshop.myshopify.io"},
+		{App{ShopDomainSuffixes: []string{"myshopify.io"}}, "myshop", "myshop.myshopify.io"},
+		{App{ShopDomainSuffixes: []string{"myshopify.io"}}, "myshop.myshopify.com", ""},
+	}
+
+	for _, c := range cases {
+		actual, err := c.app.ShopDomain(c.in)
+		if actual != c.expected {
+			t.Errorf("App.ShopDomain(%q): expected %q, actual %q", c.in, c.expected, actual)
+		}
+		if c.expected == "" && err != (ShopDomainError{c.in}) {
+			t.Errorf("App.ShopDomain(%q): expected a ShopDomainError, actual %v", c.in, err)
+		}
+	}
+}
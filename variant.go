@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -19,6 +20,13 @@ type VariantService interface {
 	Create(int, Variant) (*Variant, error)
 	Update(Variant) (*Variant, error)
 	Delete(int, int) error
+
+	ListWithContext(context.Context, int, interface{}) ([]Variant, error)
+	CountWithContext(context.Context, int, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Variant, error)
+	CreateWithContext(context.Context, int, Variant) (*Variant, error)
+	UpdateWithContext(context.Context, Variant) (*Variant, error)
+	DeleteWithContext(context.Context, int, int) error
 }
 
 // VariantServiceOp handles communication with the variant related methods of
@@ -67,45 +75,75 @@ type VariantsResource struct {
 
 // List variants
 func (s *VariantServiceOp) List(productID int, options interface{}) ([]Variant, error) {
+	return s.ListWithContext(context.Background(), productID, options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *VariantServiceOp) ListWithContext(ctx context.Context, productID int, options interface{}) ([]Variant, error) {
 	path := fmt.Sprintf("%s/%d/variants.json", productsBasePath, productID)
 	resource := new(VariantsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Variants, err
 }
 
 // Count variants
 func (s *VariantServiceOp) Count(productID int, options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), productID, options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *VariantServiceOp) CountWithContext(ctx context.Context, productID int, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/%d/variants/count.json", productsBasePath, productID)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual variant
 func (s *VariantServiceOp) Get(variantID int, options interface{}) (*Variant, error) {
+	return s.GetWithContext(context.Background(), variantID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *VariantServiceOp) GetWithContext(ctx context.Context, variantID int, options interface{}) (*Variant, error) {
 	path := fmt.Sprintf("%s/%d.json", variantsBasePath, variantID)
 	resource := new(VariantResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Variant, err
 }
 
 // Create a new variant
 func (s *VariantServiceOp) Create(productID int, variant Variant) (*Variant, error) {
+	return s.CreateWithContext(context.Background(), productID, variant)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *VariantServiceOp) CreateWithContext(ctx context.Context, productID int, variant Variant) (*Variant, error) {
 	path := fmt.Sprintf("%s/%d/variants.json", productsBasePath, productID)
 	wrappedData := VariantResource{Variant: &variant}
 	resource := new(VariantResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Variant, err
 }
 
 // Update existing variant
 func (s *VariantServiceOp) Update(variant Variant) (*Variant, error) {
+	return s.UpdateWithContext(context.Background(), variant)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *VariantServiceOp) UpdateWithContext(ctx context.Context, variant Variant) (*Variant, error) {
 	path := fmt.Sprintf("%s/%d.json", variantsBasePath, variant.ID)
 	wrappedData := VariantResource{Variant: &variant}
 	resource := new(VariantResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Variant, err
 }
 
 // Delete an existing product
 func (s *VariantServiceOp) Delete(productID int, variantID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d/variants/%d.json", productsBasePath, productID, variantID))
+	return s.DeleteWithContext(context.Background(), productID, variantID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *VariantServiceOp) DeleteWithContext(ctx context.Context, productID int, variantID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d/variants/%d.json", productsBasePath, productID, variantID))
 }
@@ -0,0 +1,122 @@
+package goshopify
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	shopifyAPIVersionHeader       = "X-Shopify-API-Version"
+	shopifyDeprecatedReasonHeader = "X-Shopify-API-Deprecated-Reason"
+)
+
+// UnstableAPIVersion is the Admin API version with features that are still in
+// development.
+const UnstableAPIVersion = "unstable"
+
+var apiVersionRegex = regexp.MustCompile(`^\d{4}-\d{2}$`)
+
+// ValidateAPIVersion returns an error if the given string is not a valid
+// Admin API version, i.e. a release such as "2019-04" or "unstable".
+func ValidateAPIVersion(version string) error {
+	if version == UnstableAPIVersion || apiVersionRegex.MatchString(version) {
+		return nil
+	}
+	return fmt.Errorf("goshopify: invalid api version %q", version)
+}
+
+// DeprecationNotice is reported when Shopify flags a request as using a
+// deprecated API feature.
+type DeprecationNotice struct {
+	Method string
+	URL    string
+
+	// The API version that served the request
+	Version string
+
+	// The reason from the X-Shopify-API-Deprecated-Reason header, usually a
+	// link to the relevant changelog entry
+	Reason string
+}
+
+// DeprecationHandler is called for every response flagged as deprecated.
+type DeprecationHandler func(DeprecationNotice)
+
+// WithDeprecationHandler makes the client call the given handler for every
+// response that uses a deprecated API feature. Without a handler, deprecations
+// are reported to the client's logger.
+func WithDeprecationHandler(handler DeprecationHandler) Option {
+	return func(c *Client) error {
+		c.deprecationHandler = handler
+		return nil
+	}
+}
+
+// APIVersion returns the Admin API version the client requests, or the empty
+// string if it uses the unversioned paths.
+func (c *Client) APIVersion() string {
+	return c.apiVersion
+}
+
+// ResponseAPIVersion returns the API version that served the last response
+// received by the client. It may differ from the requested version when that
+// version is no longer supported.
+func (c *Client) ResponseAPIVersion() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.responseVersion
+}
+
+// versionedPath rewrites an Admin API path like "admin/orders.json" to
+// "admin/api/2019-04/orders.json". OAuth paths and paths that already
+// include a version are left alone.
+func versionedPath(path, version string) string {
+	if version == "" {
+		return path
+	}
+
+	slash := strings.HasPrefix(path, "/")
+	p := strings.TrimPrefix(path, "/")
+	if !strings.HasPrefix(p, "admin/") ||
+		strings.HasPrefix(p, "admin/api/") ||
+		strings.HasPrefix(p, "admin/oauth/") {
+		return path
+	}
+
+	p = fmt.Sprintf("admin/api/%s/%s", version, strings.TrimPrefix(p, "admin/"))
+	if slash {
+		p = "/" + p
+	}
+	return p
+}
+
+// checkAPIVersion records the API version of a response and reports
+// deprecated requests.
+func (c *Client) checkAPIVersion(req *http.Request, resp *http.Response) {
+	version := resp.Header.Get(shopifyAPIVersionHeader)
+	if version != "" {
+		c.mu.Lock()
+		c.responseVersion = version
+		c.mu.Unlock()
+	}
+
+	reason := resp.Header.Get(shopifyDeprecatedReasonHeader)
+	if reason == "" {
+		return
+	}
+
+	notice := DeprecationNotice{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Version: version,
+		Reason:  reason,
+	}
+	if c.deprecationHandler != nil {
+		c.deprecationHandler(notice)
+		return
+	}
+	c.logf("goshopify: %s %s uses a deprecated api feature (version %s): %s",
+		notice.Method, notice.URL, notice.Version, notice.Reason)
+}
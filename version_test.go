@@ -0,0 +1,156 @@
+package goshopify
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestValidateAPIVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		valid   bool
+	}{
+		{"2019-04", true},
+		{"unstable", true},
+		{"", false},
+		{"2019-4", false},
+		{"latest", false},
+		{"2019-04/../", false},
+	}
+
+	for _, c := range cases {
+		err := ValidateAPIVersion(c.version)
+		if (err == nil) != c.valid {
+			t.Errorf("ValidateAPIVersion(%q) = %v, expected valid %v", c.version, err, c.valid)
+		}
+	}
+}
+
+func TestVersionedPath(t *testing.T) {
+	cases := []struct {
+		path     string
+		version  string
+		expected string
+	}{
+		{"admin/orders.json", "", "admin/orders.json"},
+		{"admin/orders.json", "2019-04", "admin/api/2019-04/orders.json"},
+		{"/admin/orders.json", "2019-04", "/admin/api/2019-04/orders.json"},
+		{"admin/products/1/metafields.json", "2019-04", "admin/api/2019-04/products/1/metafields.json"},
+		{"admin/api/2019-07/orders.json", "2019-04", "admin/api/2019-07/orders.json"},
+		{"admin/oauth/access_token", "2019-04", "admin/oauth/access_token"},
+		{"foo/bar", "2019-04", "foo/bar"},
+	}
+
+	for _, c := range cases {
+		actual := versionedPath(c.path, c.version)
+		if actual != c.expected {
+			t.Errorf("versionedPath(%q, %q) = %q, expected %q", c.path, c.version, actual, c.expected)
+		}
+	}
+}
+
+func TestNewRequestVersioned(t *testing.T) {
+	c, err := NewClientWithOptions(app, "fooshop", "abcd", WithVersion("2019-04"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() err = %v, expected nil", err)
+	}
+
+	cases := []struct {
+		inURL, outURL string
+	}{
+		{"admin/orders.json?page=1", "https://fooshop.myshopify.com/admin/api/2019-04/orders.json?page=1"},
+		{"admin/oauth/access_token", "https://fooshop.myshopify.com/admin/oauth/access_token"},
+		{"https://fooshop.myshopify.com/admin/orders.json", "https://fooshop.myshopify.com/admin/orders.json"},
+	}
+
+	for _, tc := range cases {
+		req, err := c.NewRequest("GET", tc.inURL, nil, nil)
+		if err != nil {
+			t.Fatalf("NewRequest(%v) err = %v, expected nil", tc.inURL, err)
+		}
+		if req.URL.String() != tc.outURL {
+			t.Errorf("NewRequest(%v) URL = %v, expected %v", tc.inURL, req.URL, tc.outURL)
+		}
+	}
+}
+
+func TestWithVersionInvalid(t *testing.T) {
+	_, err := NewClientWithOptions(app, "fooshop", "abcd", WithVersion("2019/04"))
+	if err == nil {
+		t.Errorf("NewClientWithOptions() err = nil, expected an error")
+	}
+}
+
+func TestVersionedServiceRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var notices []DeprecationNotice
+	c, _ := NewClientWithOptions(app, "fooshop", "abcd",
+		WithVersion("2019-04"),
+		WithDeprecationHandler(func(n DeprecationNotice) {
+			notices = append(notices, n)
+		}),
+	)
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/api/2019-04/products/1/metafields.json",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"metafields": [{"id":1}]}`)
+			resp.Header.Set("X-Shopify-API-Version", "2019-07")
+			resp.Header.Set("X-Shopify-API-Deprecated-Reason", "https://help.shopify.com/api/getting-started/api-deprecations")
+			return resp, nil
+		})
+
+	metafields, err := c.Product.ListMetafields(1, nil)
+	if err != nil {
+		t.Fatalf("Product.ListMetafields returned error: %v", err)
+	}
+	if len(metafields) != 1 {
+		t.Errorf("Product.ListMetafields returned %+v, expected one metafield", metafields)
+	}
+
+	if c.APIVersion() != "2019-04" {
+		t.Errorf("Client.APIVersion() = %v, expected %v", c.APIVersion(), "2019-04")
+	}
+	if c.ResponseAPIVersion() != "2019-07" {
+		t.Errorf("Client.ResponseAPIVersion() = %v, expected %v", c.ResponseAPIVersion(), "2019-07")
+	}
+
+	expected := []DeprecationNotice{{
+		Method:  "GET",
+		URL:     "https://fooshop.myshopify.com/admin/api/2019-04/products/1/metafields.json",
+		Version: "2019-07",
+		Reason:  "https://help.shopify.com/api/getting-started/api-deprecations",
+	}}
+	if !reflect.DeepEqual(notices, expected) {
+		t.Errorf("DeprecationHandler got %+v, expected %+v", notices, expected)
+	}
+}
+
+func TestDeprecationLogged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logger := &testLogger{}
+	c, _ := NewClientWithOptions(app, "fooshop", "abcd", WithLogger(logger))
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/shop.json",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `{"shop": {"id":1}}`)
+			resp.Header.Set("X-Shopify-API-Deprecated-Reason", "old")
+			return resp, nil
+		})
+
+	_, err := c.Shop.Get(nil)
+	if err != nil {
+		t.Fatalf("Shop.Get returned error: %v", err)
+	}
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "deprecated") {
+		t.Errorf("Shop.Get logged %v, expected a deprecation", logger.lines)
+	}
+}
@@ -1,6 +1,7 @@
 package goshopify
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -12,11 +13,25 @@ const webhooksBasePath = "admin/webhooks"
 // See: https://help.shopify.com/api/reference/webhook
 type WebhookService interface {
 	List(interface{}) ([]Webhook, error)
+	ListPage(interface{}) ([]Webhook, *Pagination, error)
+	ListAll(interface{}) ([]Webhook, error)
 	Count(interface{}) (int, error)
 	Get(int, interface{}) (*Webhook, error)
 	Create(Webhook) (*Webhook, error)
 	Update(Webhook) (*Webhook, error)
 	Delete(int) error
+	Sync([]Webhook, *WebhookSyncOptions) (*WebhookSyncReport, error)
+
+	ListWithContext(context.Context, interface{}) ([]Webhook, error)
+	ListPageWithContext(context.Context, interface{}) ([]Webhook, *Pagination, error)
+	ListAllWithContext(context.Context, interface{}) ([]Webhook, error)
+	Iterate(context.Context, interface{}) <-chan WebhookResult
+	CountWithContext(context.Context, interface{}) (int, error)
+	GetWithContext(context.Context, int, interface{}) (*Webhook, error)
+	CreateWithContext(context.Context, Webhook) (*Webhook, error)
+	UpdateWithContext(context.Context, Webhook) (*Webhook, error)
+	DeleteWithContext(context.Context, int) error
+	SyncWithContext(context.Context, []Webhook, *WebhookSyncOptions) (*WebhookSyncReport, error)
 }
 
 // WebhookServiceOp handles communication with the webhook-related methods of
@@ -53,47 +68,304 @@ type WebhooksResource struct {
 	Webhooks []Webhook `json:"webhooks"`
 }
 
+// WebhookSyncOptions can be used to change the behaviour of Sync.
+type WebhookSyncOptions struct {
+	// DryRun only reports the changes Sync would make.
+	DryRun bool
+}
+
+// WebhookSyncReport lists the changes made by Sync. On a dry run, it lists the
+// changes that would be made and created webhooks have no ID.
+type WebhookSyncReport struct {
+	Created   []Webhook
+	Updated   []Webhook
+	Deleted   []Webhook
+	Unchanged []Webhook
+}
+
+// WebhookResult is a webhook or an error from Iterate.
+type WebhookResult struct {
+	Webhook Webhook
+	Err     error
+}
+
 // List webhooks
 func (s *WebhookServiceOp) List(options interface{}) ([]Webhook, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext is like List but uses ctx for the request.
+func (s *WebhookServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Webhook, error) {
 	path := fmt.Sprintf("%s.json", webhooksBasePath)
 	resource := new(WebhooksResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Webhooks, err
 }
 
+// ListPage lists a page of webhooks along with its pagination
+func (s *WebhookServiceOp) ListPage(options interface{}) ([]Webhook, *Pagination, error) {
+	return s.ListPageWithContext(context.Background(), options)
+}
+
+// ListPageWithContext is like ListPage but uses ctx for the request.
+func (s *WebhookServiceOp) ListPageWithContext(ctx context.Context, options interface{}) ([]Webhook, *Pagination, error) {
+	path := fmt.Sprintf("%s.json", webhooksBasePath)
+	resource := new(WebhooksResource)
+	pagination, err := s.client.ListPageWithContext(ctx, path, resource, options)
+	return resource.Webhooks, pagination, err
+}
+
+// ListAll lists all webhooks, following the pagination from the given options
+func (s *WebhookServiceOp) ListAll(options interface{}) ([]Webhook, error) {
+	return s.ListAllWithContext(context.Background(), options)
+}
+
+// ListAllWithContext is like ListAll but uses ctx for the requests.
+func (s *WebhookServiceOp) ListAllWithContext(ctx context.Context, options interface{}) ([]Webhook, error) {
+	var collector []Webhook
+	err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+		webhooks, pagination, err := s.ListPageWithContext(ctx, options)
+		if err == nil {
+			collector = append(collector, webhooks...)
+		}
+		return pagination, err
+	})
+	return collector, err
+}
+
+// Iterate streams all webhooks over the returned channel, fetching the pages
+// lazily. The channel is closed after the last webhook or after a result
+// with an error. Cancel ctx to stop early.
+func (s *WebhookServiceOp) Iterate(ctx context.Context, options interface{}) <-chan WebhookResult {
+	results := make(chan WebhookResult)
+	go func() {
+		defer close(results)
+		err := s.client.listAll(ctx, options, func(options interface{}) (*Pagination, error) {
+			webhooks, pagination, err := s.ListPageWithContext(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			for _, webhook := range webhooks {
+				select {
+				case results <- WebhookResult{Webhook: webhook}:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return pagination, nil
+		})
+		if err != nil {
+			select {
+			case results <- WebhookResult{Err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return results
+}
+
 // Count webhooks
 func (s *WebhookServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (s *WebhookServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
 	path := fmt.Sprintf("%s/count.json", webhooksBasePath)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual webhook
 func (s *WebhookServiceOp) Get(webhookdID int, options interface{}) (*Webhook, error) {
+	return s.GetWithContext(context.Background(), webhookdID, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (s *WebhookServiceOp) GetWithContext(ctx context.Context, webhookdID int, options interface{}) (*Webhook, error) {
 	path := fmt.Sprintf("%s/%d.json", webhooksBasePath, webhookdID)
 	resource := new(WebhookResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Webhook, err
 }
 
-// Create a new webhook
+// Create a new webhook. An error is returned without calling Shopify if the
+// address is not valid, see ParseWebhookAddress. Topics that are not a known
+// WebhookTopic are left to Shopify to accept or refuse, with a warning logged
+// as they may be misspelled.
 func (s *WebhookServiceOp) Create(webhook Webhook) (*Webhook, error) {
+	return s.CreateWithContext(context.Background(), webhook)
+}
+
+// CreateWithContext is like Create but uses ctx for the request.
+func (s *WebhookServiceOp) CreateWithContext(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	if !WebhookTopic(webhook.Topic).Valid() {
+		s.client.logf("goshopify: creating webhook for unknown topic %q", webhook.Topic)
+	}
+	if _, err := ParseWebhookAddress(webhook.Address); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("%s.json", webhooksBasePath)
 	wrappedData := WebhookResource{Webhook: &webhook}
 	resource := new(WebhookResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Webhook, err
 }
 
-// Update an existing webhook.
+// Update an existing webhook. An error is returned without calling Shopify if
+// the address is set but not valid, see ParseWebhookAddress.
 func (s *WebhookServiceOp) Update(webhook Webhook) (*Webhook, error) {
+	return s.UpdateWithContext(context.Background(), webhook)
+}
+
+// UpdateWithContext is like Update but uses ctx for the request.
+func (s *WebhookServiceOp) UpdateWithContext(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	if webhook.Address != "" {
+		if _, err := ParseWebhookAddress(webhook.Address); err != nil {
+			return nil, err
+		}
+	}
+
 	path := fmt.Sprintf("%s/%d.json", webhooksBasePath, webhook.ID)
 	wrappedData := WebhookResource{Webhook: &webhook}
 	resource := new(WebhookResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Webhook, err
 }
 
 // Delete an existing webhooks
 func (s *WebhookServiceOp) Delete(ID int) error {
-	return s.client.Delete(fmt.Sprintf("%s/%d.json", webhooksBasePath, ID))
+	return s.DeleteWithContext(context.Background(), ID)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (s *WebhookServiceOp) DeleteWithContext(ctx context.Context, ID int) error {
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%d.json", webhooksBasePath, ID))
+}
+
+// Sync makes the webhooks of the shop match the desired ones. Webhooks are
+// matched by topic and address. Missing webhooks are created, webhooks with a
+// different format or fields are updated and webhooks that aren't desired are
+// deleted. The returned report lists the changes, also when an error stopped
+// the sync halfway.
+func (s *WebhookServiceOp) Sync(desired []Webhook, options *WebhookSyncOptions) (*WebhookSyncReport, error) {
+	return s.SyncWithContext(context.Background(), desired, options)
+}
+
+// SyncWithContext is like Sync but uses ctx for the requests.
+func (s *WebhookServiceOp) SyncWithContext(ctx context.Context, desired []Webhook, options *WebhookSyncOptions) (*WebhookSyncReport, error) {
+	if options == nil {
+		options = &WebhookSyncOptions{}
+	}
+
+	existing, err := s.ListAllWithContext(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index the existing webhooks, any duplicates are deleted
+	report := new(WebhookSyncReport)
+	var extra []Webhook
+	byKey := map[string]Webhook{}
+	for _, webhook := range existing {
+		key := webhookSyncKey(webhook)
+		if _, ok := byKey[key]; ok {
+			extra = append(extra, webhook)
+			continue
+		}
+		byKey[key] = webhook
+	}
+
+	var create, update []Webhook
+	seen := map[string]bool{}
+	for _, webhook := range desired {
+		if webhook.Format == "" {
+			webhook.Format = "json"
+		}
+		key := webhookSyncKey(webhook)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate desired webhook for topic %s and address %s", webhook.Topic, webhook.Address)
+		}
+		seen[key] = true
+
+		current, ok := byKey[key]
+		switch {
+		case !ok:
+			webhook.ID = 0
+			create = append(create, webhook)
+		case webhookDrifted(current, webhook):
+			webhook.ID = current.ID
+			update = append(update, webhook)
+		default:
+			report.Unchanged = append(report.Unchanged, current)
+		}
+	}
+	for _, webhook := range existing {
+		if !seen[webhookSyncKey(webhook)] {
+			extra = append(extra, webhook)
+		}
+	}
+
+	if options.DryRun {
+		report.Created = create
+		report.Updated = update
+		report.Deleted = extra
+		return report, nil
+	}
+
+	// Create and update before deleting so that no events are missed while
+	// moving a webhook to another address
+	for _, webhook := range create {
+		created, err := s.CreateWithContext(ctx, webhook)
+		if err != nil {
+			return report, err
+		}
+		report.Created = append(report.Created, *created)
+	}
+	for _, webhook := range update {
+		updated, err := s.UpdateWithContext(ctx, webhook)
+		if err != nil {
+			return report, err
+		}
+		report.Updated = append(report.Updated, *updated)
+	}
+	for _, webhook := range extra {
+		if err := s.DeleteWithContext(ctx, webhook.ID); err != nil {
+			return report, err
+		}
+		report.Deleted = append(report.Deleted, webhook)
+	}
+
+	return report, nil
+}
+
+// webhookSyncKey identifies a webhook subscription for Sync.
+func webhookSyncKey(webhook Webhook) string {
+	return webhook.Topic + " " + webhook.Address
+}
+
+// webhookDrifted reports whether an existing webhook differs from the desired
+// one in anything but its topic and address.
+func webhookDrifted(existing, desired Webhook) bool {
+	return existing.Format != desired.Format ||
+		!sameStrings(existing.Fields, desired.Fields) ||
+		!sameStrings(existing.MetafieldNamespaces, desired.MetafieldNamespaces)
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+	return true
 }
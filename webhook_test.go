@@ -112,7 +112,7 @@ func TestWebhookCreate(t *testing.T) {
 
 	webhook := Webhook{
 		Topic:   "orders/create",
-		Address: "http://example.com",
+		Address: "https://example.com",
 	}
 
 	returnedWebhook, err := client.Webhook.Create(webhook)
@@ -133,7 +133,7 @@ func TestWebhookUpdate(t *testing.T) {
 	webhook := Webhook{
 		ID:      4759306,
 		Topic:   "orders/create",
-		Address: "http://example.com",
+		Address: "https://example.com",
 	}
 
 	returnedWebhook, err := client.Webhook.Update(webhook)
@@ -156,3 +156,130 @@ func TestWebhookDelete(t *testing.T) {
 		t.Errorf("Webhook.Delete returned error: %v", err)
 	}
 }
+
+func TestWebhookSync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/webhooks.json",
+		httpmock.NewStringResponder(200, `{"webhooks": [
+			{"id": 1, "topic": "orders/create", "address": "https://example.com/webhooks", "format": "json", "fields": ["updated_at", "id"]},
+			{"id": 2, "topic": "products/update", "address": "https://example.com/webhooks", "format": "xml"},
+			{"id": 3, "topic": "customers/create", "address": "https://example.com/webhooks", "format": "json"},
+			{"id": 4, "topic": "orders/create", "address": "https://example.com/webhooks", "format": "json"}
+		]}`))
+
+	desired := []Webhook{
+		{Topic: "orders/create", Address: "https://example.com/webhooks", Fields: []string{"id", "updated_at"}},
+		{Topic: "products/update", Address: "https://example.com/webhooks", Format: "json"},
+		{Topic: "orders/paid", Address: "https://example.com/webhooks"},
+	}
+
+	report, err := client.Webhook.Sync(desired, &WebhookSyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Webhook.Sync returned error: %v", err)
+	}
+
+	expected := &WebhookSyncReport{
+		Created: []Webhook{{Topic: "orders/paid", Address: "https://example.com/webhooks", Format: "json"}},
+		Updated: []Webhook{{ID: 2, Topic: "products/update", Address: "https://example.com/webhooks", Format: "json"}},
+		Deleted: []Webhook{
+			{ID: 4, Topic: "orders/create", Address: "https://example.com/webhooks", Format: "json"},
+			{ID: 3, Topic: "customers/create", Address: "https://example.com/webhooks", Format: "json"},
+		},
+		Unchanged: []Webhook{{ID: 1, Topic: "orders/create", Address: "https://example.com/webhooks", Format: "json", Fields: []string{"updated_at", "id"}}},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Webhook.Sync dry run returned %+v, expected %+v", report, expected)
+	}
+
+	// Nothing was changed on the dry run
+	if info := httpmock.GetCallCountInfo(); len(info) != 1 {
+		t.Errorf("Webhook.Sync dry run made calls %v, expected only a list", info)
+	}
+
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/webhooks.json",
+		httpmock.NewStringResponder(201, `{"webhook": {"id": 5, "topic": "orders/paid", "address": "https://example.com/webhooks", "format": "json"}}`))
+	httpmock.RegisterResponder("PUT", "https://fooshop.myshopify.com/admin/webhooks/2.json",
+		httpmock.NewStringResponder(200, `{"webhook": {"id": 2, "topic": "products/update", "address": "https://example.com/webhooks", "format": "json"}}`))
+	httpmock.RegisterResponder("DELETE", "https://fooshop.myshopify.com/admin/webhooks/3.json",
+		httpmock.NewStringResponder(200, "{}"))
+	httpmock.RegisterResponder("DELETE", "https://fooshop.myshopify.com/admin/webhooks/4.json",
+		httpmock.NewStringResponder(200, "{}"))
+
+	report, err = client.Webhook.Sync(desired, nil)
+	if err != nil {
+		t.Fatalf("Webhook.Sync returned error: %v", err)
+	}
+
+	expected.Created[0].ID = 5
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Webhook.Sync returned %+v, expected %+v", report, expected)
+	}
+}
+
+func TestWebhookSyncError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	httpmock.RegisterResponder("GET", "https://fooshop.myshopify.com/admin/webhooks.json",
+		httpmock.NewStringResponder(200, `{"webhooks": [{"id": 1, "topic": "orders/create", "address": "https://example.com/old", "format": "json"}]}`))
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/webhooks.json",
+		httpmock.NewStringResponder(422, `{"errors": {"address": ["for this topic has already been taken"]}}`))
+
+	desired := []Webhook{{Topic: "orders/create", Address: "https://example.com/new"}}
+	report, err := client.Webhook.Sync(desired, nil)
+	if err == nil {
+		t.Fatal("Webhook.Sync returned no error")
+	}
+
+	// The old webhook is kept when the new one can't be created
+	if len(report.Created) != 0 || len(report.Deleted) != 0 {
+		t.Errorf("Webhook.Sync returned %+v, expected no changes", report)
+	}
+
+	_, err = client.Webhook.Sync(append(desired, desired...), nil)
+	if err == nil {
+		t.Error("Webhook.Sync returned no error for duplicate desired webhooks")
+	}
+}
+
+func TestWebhookCreateUnknownTopic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logger := &testLogger{}
+	client.logger = logger
+	httpmock.RegisterResponder("POST", "https://fooshop.myshopify.com/admin/webhooks.json",
+		httpmock.NewStringResponder(201, `{"webhook": {"id": 1, "topic": "orders/foo", "address": "https://example.com"}}`))
+
+	// Topics added to the Admin API after this package are left to Shopify
+	webhook, err := client.Webhook.Create(Webhook{Topic: "orders/foo", Address: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Webhook.Create returned error: %v", err)
+	}
+	if webhook.ID != 1 {
+		t.Errorf("Webhook.Create returned %+v, expected the created webhook", webhook)
+	}
+	expected := []string{`goshopify: creating webhook for unknown topic "orders/foo"`}
+	if !reflect.DeepEqual(logger.lines, expected) {
+		t.Errorf("Webhook.Create logged %q, expected %q", logger.lines, expected)
+	}
+}
+
+func TestWebhookCreateInvalidAddress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Webhook.Create(Webhook{Topic: "orders/create", Address: "example.com/webhooks"})
+	if err == nil || err.Error() != `goshopify: invalid webhook address "example.com/webhooks"` {
+		t.Errorf("Webhook.Create returned error %v, expected an invalid address error", err)
+	}
+	_, err = client.Webhook.Update(Webhook{ID: 1, Address: "pubsub://my-project"})
+	if err == nil {
+		t.Error("Webhook.Update returned no error for an invalid address")
+	}
+	if info := httpmock.GetCallCountInfo(); len(info) != 0 {
+		t.Errorf("Webhook made calls %v, expected none", info)
+	}
+}
@@ -0,0 +1,116 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// WebhookTransport is the way webhooks are delivered to an address.
+type WebhookTransport string
+
+// Webhook transports supported by Shopify
+const (
+	WebhookTransportHTTP        WebhookTransport = "http"
+	WebhookTransportEventBridge WebhookTransport = "eventbridge"
+	WebhookTransportPubSub      WebhookTransport = "pubsub"
+)
+
+var (
+	eventBridgeAddressRegex = regexp.MustCompile(`^arn:aws:events:[a-z0-9-]+::event-source/aws\.partner/shopify\.com/[0-9]+/[A-Za-z0-9._/-]+$`)
+	pubSubAddressRegex      = regexp.MustCompile(`^pubsub://[a-z][a-z0-9-]{4,28}[a-z0-9]:[A-Za-z][A-Za-z0-9._~%+-]{2,254}$`)
+)
+
+// EventBridgeWebhookAddress returns the address for webhooks delivered to an
+// Amazon EventBridge partner event source of the app, e.g.
+// "arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1234/orders".
+func EventBridgeWebhookAddress(region string, appID int, eventSourceName string) string {
+	return fmt.Sprintf("arn:aws:events:%s::event-source/aws.partner/shopify.com/%d/%s", region, appID, eventSourceName)
+}
+
+// PubSubWebhookAddress returns the address for webhooks delivered to a Google
+// Cloud Pub/Sub topic, e.g. "pubsub://my-project:shopify-webhooks". The
+// topic must grant Shopify's service account permission to publish.
+func PubSubWebhookAddress(projectID, topicID string) string {
+	return fmt.Sprintf("pubsub://%s:%s", projectID, topicID)
+}
+
+// ParseWebhookAddress returns the transport of a webhook address, or an error
+// if it is not a valid HTTPS URL, EventBridge event source ARN or Pub/Sub
+// topic. Shopify refuses plain HTTP endpoints.
+func ParseWebhookAddress(address string) (WebhookTransport, error) {
+	switch {
+	case eventBridgeAddressRegex.MatchString(address):
+		return WebhookTransportEventBridge, nil
+	case pubSubAddressRegex.MatchString(address):
+		return WebhookTransportPubSub, nil
+	}
+
+	u, err := url.Parse(address)
+	if err == nil && u.Scheme == "https" && u.Host != "" {
+		return WebhookTransportHTTP, nil
+	}
+	return "", fmt.Errorf("goshopify: invalid webhook address %q", address)
+}
+
+// eventBridgeWebhookEvent is the event Shopify puts on an EventBridge event
+// bus.
+type eventBridgeWebhookEvent struct {
+	Detail struct {
+		Payload  json.RawMessage   `json:"payload"`
+		Metadata map[string]string `json:"metadata"`
+	} `json:"detail"`
+}
+
+// DecodeEventBridgeWebhook returns the delivery in an EventBridge event, e.g.
+// the event passed to a Lambda function that is the target of a rule on the
+// app's event bus. The headers of the webhook are taken from the metadata of
+// the event. EventBridge authenticates Shopify instead of the HMAC, which is
+// not verified.
+func DecodeEventBridgeWebhook(event []byte) (WebhookDelivery, error) {
+	e := eventBridgeWebhookEvent{}
+	if err := json.Unmarshal(event, &e); err != nil {
+		return WebhookDelivery{}, err
+	}
+	return newWebhookDelivery(webhookMetadataHeader(e.Detail.Metadata), []byte(e.Detail.Payload))
+}
+
+// pubSubPushRequest is the body of a request from a Pub/Sub push
+// subscription.
+type pubSubPushRequest struct {
+	Message struct {
+		Attributes map[string]string `json:"attributes"`
+		Data       []byte            `json:"data"`
+	} `json:"message"`
+}
+
+// NewPubSubWebhookDelivery returns the delivery in a Pub/Sub message, with
+// the headers of the webhook taken from the attributes and the payload from
+// the data of the message. Pub/Sub authenticates Shopify instead of the HMAC,
+// which is not verified.
+func NewPubSubWebhookDelivery(attributes map[string]string, data []byte) (WebhookDelivery, error) {
+	return newWebhookDelivery(webhookMetadataHeader(attributes), data)
+}
+
+// DecodePubSubWebhook returns the delivery in the body of a request from a
+// Pub/Sub push subscription. Use NewPubSubWebhookDelivery for messages from
+// pull subscriptions.
+func DecodePubSubWebhook(body []byte) (WebhookDelivery, error) {
+	req := pubSubPushRequest{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return WebhookDelivery{}, err
+	}
+	return NewPubSubWebhookDelivery(req.Message.Attributes, req.Message.Data)
+}
+
+// webhookMetadataHeader returns the headers of a webhook delivered by
+// EventBridge or Pub/Sub, whose keys are not canonical.
+func webhookMetadataHeader(metadata map[string]string) http.Header {
+	header := http.Header{}
+	for key, value := range metadata {
+		header.Set(key, value)
+	}
+	return header
+}
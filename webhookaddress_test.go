@@ -0,0 +1,162 @@
+package goshopify
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhookAddresses(t *testing.T) {
+	eventBridge := EventBridgeWebhookAddress("us-east-1", 1234, "orders")
+	expected := "arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1234/orders"
+	if eventBridge != expected {
+		t.Errorf("EventBridgeWebhookAddress returned %q, expected %q", eventBridge, expected)
+	}
+
+	pubSub := PubSubWebhookAddress("my-project", "shopify-webhooks")
+	expected = "pubsub://my-project:shopify-webhooks"
+	if pubSub != expected {
+		t.Errorf("PubSubWebhookAddress returned %q, expected %q", pubSub, expected)
+	}
+}
+
+func TestParseWebhookAddress(t *testing.T) {
+	cases := []struct {
+		address  string
+		expected WebhookTransport
+	}{
+		{"https://example.com/webhooks", WebhookTransportHTTP},
+		{"HTTPS://example.com", WebhookTransportHTTP},
+		{"http://example.com", ""},
+		{"arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/1234/orders", WebhookTransportEventBridge},
+		{"pubsub://my-project:shopify-webhooks", WebhookTransportPubSub},
+		{"", ""},
+		{"example.com/webhooks", ""},
+		{"ftp://example.com", ""},
+		{"https://", ""},
+		{"arn:aws:events:us-east-1::event-source/aws.partner/shopify.com/app/orders", ""},
+		{"arn:aws:sqs:us-east-1:123456789012:orders", ""},
+		{"pubsub://my-project", ""},
+		{"pubsub://p:shopify-webhooks", ""},
+		{"pubsub://my-project:1topic", ""},
+	}
+	for _, c := range cases {
+		actual, err := ParseWebhookAddress(c.address)
+		if actual != c.expected || (err == nil) != (c.expected != "") {
+			t.Errorf("ParseWebhookAddress(%q) returned %q, %v, expected %q", c.address, actual, err, c.expected)
+		}
+	}
+}
+
+func TestDecodeEventBridgeWebhook(t *testing.T) {
+	event := `{
+		"version": "0",
+		"id": "1b4e6a1c-0a5d-2b1b-4c7f-1f1e0d1b0b0b",
+		"detail-type": "shopifyWebhook",
+		"source": "aws.partner/shopify.com/1234/orders",
+		"account": "123456789012",
+		"time": "2019-04-01T12:00:00Z",
+		"region": "us-east-1",
+		"resources": [],
+		"detail": {
+			"payload": {"id": 1, "email": "foo@example.com"},
+			"metadata": {
+				"Content-Type": "application/json",
+				"X-Shopify-Topic": "orders/create",
+				"X-Shopify-Shop-Domain": "fooshop.myshopify.com",
+				"X-Shopify-Hmac-SHA256": "c2VjcmV0",
+				"X-Shopify-Webhook-Id": "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+				"X-Shopify-API-Version": "2019-04",
+				"X-Shopify-Triggered-At": "2019-04-01T12:00:00Z"
+			}
+		}
+	}`
+
+	delivery, err := DecodeEventBridgeWebhook([]byte(event))
+	if err != nil {
+		t.Fatalf("DecodeEventBridgeWebhook returned error: %v", err)
+	}
+
+	expected := WebhookDelivery{
+		Topic:       WebhookTopicOrdersCreate,
+		ShopDomain:  "fooshop.myshopify.com",
+		WebhookID:   "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+		APIVersion:  "2019-04",
+		TriggeredAt: time.Date(2019, time.April, 1, 12, 0, 0, 0, time.UTC),
+		Body:        []byte(`{"id": 1, "email": "foo@example.com"}`),
+	}
+	if !reflect.DeepEqual(delivery, expected) {
+		t.Errorf("DecodeEventBridgeWebhook returned %+v, expected %+v", delivery, expected)
+	}
+
+	payload, err := delivery.Payload()
+	if err != nil {
+		t.Fatalf("WebhookDelivery.Payload returned error: %v", err)
+	}
+	if order, ok := payload.(*Order); !ok || order.ID != 1 {
+		t.Errorf("WebhookDelivery.Payload returned %#v, expected order 1", payload)
+	}
+}
+
+func TestDecodeEventBridgeWebhookErrors(t *testing.T) {
+	cases := []string{
+		`{`,
+		`{"detail": {"payload": {}, "metadata": {"X-Shopify-Shop-Domain": "fooshop.myshopify.com"}}}`,
+		`{"detail": {"payload": {}, "metadata": {"X-Shopify-Topic": "orders/create", "X-Shopify-Shop-Domain": "fooshop.myshopify.com", "X-Shopify-Triggered-At": "yesterday"}}}`,
+	}
+	for _, c := range cases {
+		if _, err := DecodeEventBridgeWebhook([]byte(c)); err == nil {
+			t.Errorf("DecodeEventBridgeWebhook(%s) returned no error", c)
+		}
+	}
+}
+
+func TestDecodePubSubWebhook(t *testing.T) {
+	// The data is base64 for {"id":1}
+	body := `{
+		"message": {
+			"attributes": {
+				"X-Shopify-Topic": "products/update",
+				"X-Shopify-Shop-Domain": "fooshop.myshopify.com",
+				"X-Shopify-Webhook-Id": "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043"
+			},
+			"data": "eyJpZCI6MX0=",
+			"messageId": "2070443601311540",
+			"publishTime": "2019-04-01T12:00:00.000Z"
+		},
+		"subscription": "projects/my-project/subscriptions/shopify-webhooks"
+	}`
+
+	delivery, err := DecodePubSubWebhook([]byte(body))
+	if err != nil {
+		t.Fatalf("DecodePubSubWebhook returned error: %v", err)
+	}
+
+	expected := WebhookDelivery{
+		Topic:      WebhookTopicProductsUpdate,
+		ShopDomain: "fooshop.myshopify.com",
+		WebhookID:  "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+		Body:       []byte(`{"id":1}`),
+	}
+	if !reflect.DeepEqual(delivery, expected) {
+		t.Errorf("DecodePubSubWebhook returned %+v, expected %+v", delivery, expected)
+	}
+
+	if _, err := DecodePubSubWebhook([]byte(`{"message": {"data": "eyJpZCI6MX0="}}`)); err == nil {
+		t.Error("DecodePubSubWebhook returned no error for a message without attributes")
+	}
+}
+
+func TestNewPubSubWebhookDelivery(t *testing.T) {
+	attributes := map[string]string{
+		"x-shopify-topic":       "shop/update",
+		"x-shopify-shop-domain": "fooshop.myshopify.com",
+	}
+	delivery, err := NewPubSubWebhookDelivery(attributes, []byte(`{"id":1}`))
+	if err != nil {
+		t.Fatalf("NewPubSubWebhookDelivery returned error: %v", err)
+	}
+	if delivery.Topic != WebhookTopicShopUpdate || delivery.ShopDomain != "fooshop.myshopify.com" {
+		t.Errorf("NewPubSubWebhookDelivery returned %+v", delivery)
+	}
+}
@@ -0,0 +1,150 @@
+package goshopify
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const shopifyTriggeredAtHeader = "X-Shopify-Triggered-At"
+
+const (
+	// DefaultWebhookStoreSize is the number of webhook IDs remembered by the
+	// WebhookStore of a new WebhookHandler.
+	DefaultWebhookStoreSize = 10000
+
+	// DefaultWebhookDedupTTL is how long the IDs of processed webhooks are
+	// remembered by default. Shopify retries failed deliveries for 48 hours.
+	DefaultWebhookDedupTTL = 48 * time.Hour
+
+	// DefaultWebhookReserveTTL is how long a webhook ID stays reserved while
+	// its delivery is processed by default. A reservation that is neither
+	// marked done nor released, e.g. because the process died, expires after
+	// it so that a redelivery is processed.
+	DefaultWebhookReserveTTL = 5 * time.Minute
+)
+
+// WebhookStatus is the state of a webhook ID in a WebhookStore.
+type WebhookStatus int
+
+const (
+	// WebhookReserved means the ID was reserved for the caller, who must
+	// process the delivery and then mark it done or release it.
+	WebhookReserved WebhookStatus = iota
+
+	// WebhookInProgress means another delivery of the webhook is being
+	// processed. It may still fail, so the delivery must not be
+	// acknowledged.
+	WebhookInProgress
+
+	// WebhookDone means the webhook was processed already.
+	WebhookDone
+)
+
+// WebhookStore remembers the IDs of webhooks being processed and processed,
+// so that repeated deliveries of the same webhook can be skipped. Implement
+// it on top of a shared database to deduplicate across processes.
+//
+// IDs are tracked in two steps: Reserve marks an ID as in progress before
+// processing it and Done marks it processed afterwards. A redelivery that
+// arrives in between, e.g. because Shopify timed out waiting for the first
+// delivery, is rejected so that Shopify retries it in case the first
+// delivery fails.
+type WebhookStore interface {
+	// Reserve marks the ID as in progress for the given duration, unless it
+	// is in progress or done already, and returns its status. Implementations
+	// must do this atomically.
+	Reserve(ctx context.Context, id string, ttl time.Duration) (WebhookStatus, error)
+
+	// Done marks a reserved ID as processed for the given duration.
+	Done(ctx context.Context, id string, ttl time.Duration) error
+
+	// Release forgets a reserved ID, so that a redelivery of a webhook that
+	// failed is processed again.
+	Release(ctx context.Context, id string) error
+}
+
+// MemoryWebhookStore is an in-memory WebhookStore holding a limited number of
+// IDs. When it is full the least recently seen ID is evicted. It is safe for
+// concurrent use.
+type MemoryWebhookStore struct {
+	size    int
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryWebhookEntry struct {
+	id      string
+	status  WebhookStatus
+	expires time.Time
+}
+
+// NewMemoryWebhookStore returns a MemoryWebhookStore holding up to size IDs.
+func NewMemoryWebhookStore(size int) *MemoryWebhookStore {
+	return &MemoryWebhookStore{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Reserve marks the ID as in progress until the TTL passes or it is evicted.
+func (s *MemoryWebhookStore) Reserve(ctx context.Context, id string, ttl time.Duration) (WebhookStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok {
+		entry := e.Value.(*memoryWebhookEntry)
+		if time.Now().Before(entry.expires) {
+			s.order.MoveToFront(e)
+			return entry.status, nil
+		}
+	}
+	s.set(id, WebhookInProgress, ttl)
+	return WebhookReserved, nil
+}
+
+// Done marks the ID as processed until the TTL passes or it is evicted.
+func (s *MemoryWebhookStore) Done(ctx context.Context, id string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(id, WebhookDone, ttl)
+	return nil
+}
+
+// set records the ID with the status. The caller must hold the lock.
+func (s *MemoryWebhookStore) set(id string, status WebhookStatus, ttl time.Duration) {
+	if e, ok := s.entries[id]; ok {
+		s.order.Remove(e)
+		delete(s.entries, id)
+	}
+
+	s.entries[id] = s.order.PushFront(&memoryWebhookEntry{id: id, status: status, expires: time.Now().Add(ttl)})
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryWebhookEntry).id)
+	}
+}
+
+// Release forgets the ID.
+func (s *MemoryWebhookStore) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok {
+		s.order.Remove(e)
+		delete(s.entries, id)
+	}
+	return nil
+}
+
+// Len returns the number of IDs in the store, including expired ones that
+// haven't been evicted yet.
+func (s *MemoryWebhookStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
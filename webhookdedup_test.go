@@ -0,0 +1,176 @@
+package goshopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryWebhookStore(t *testing.T) {
+	store := NewMemoryWebhookStore(2)
+	ctx := context.Background()
+
+	reserve := func(id string, ttl time.Duration, expected WebhookStatus) {
+		status, err := store.Reserve(ctx, id, ttl)
+		if err != nil {
+			t.Fatalf("MemoryWebhookStore.Reserve(%q) returned error: %v", id, err)
+		}
+		if status != expected {
+			t.Errorf("MemoryWebhookStore.Reserve(%q) = %v, expected %v", id, status, expected)
+		}
+	}
+
+	reserve("a", time.Hour, WebhookReserved)
+	reserve("a", time.Hour, WebhookInProgress)
+	store.Done(ctx, "a", time.Hour)
+	reserve("a", time.Hour, WebhookDone)
+	reserve("b", time.Hour, WebhookReserved)
+
+	// a was seen more recently than b, so b is evicted
+	reserve("a", time.Hour, WebhookDone)
+	reserve("c", time.Hour, WebhookReserved)
+	if store.Len() != 2 {
+		t.Errorf("MemoryWebhookStore.Len() = %d, expected 2", store.Len())
+	}
+	reserve("a", time.Hour, WebhookDone)
+	reserve("b", time.Hour, WebhookReserved)
+
+	store.Release(ctx, "b")
+	reserve("b", time.Hour, WebhookReserved)
+
+	// Expired reservations are reserved again
+	reserve("d", time.Nanosecond, WebhookReserved)
+	time.Sleep(time.Millisecond)
+	reserve("d", time.Hour, WebhookReserved)
+}
+
+func TestWebhookHandlerDedupOverlapping(t *testing.T) {
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+
+	started := make(chan struct{})
+	finish := make(chan error)
+	calls := 0
+	handler.HandleFunc("orders/create", func(ctx context.Context, d WebhookDelivery) error {
+		calls++
+		if calls == 1 {
+			close(started)
+			return <-finish
+		}
+		return nil
+	})
+
+	req := webhookRequest("hush", "orders/create", `{"id": 1}`)
+	deliver := func() int {
+		r := httptest.NewRequest("POST", "https://example.com/webhooks", strings.NewReader(`{"id": 1}`))
+		r.Header = req.Header
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// The first delivery is still running when Shopify times out and
+	// delivers the webhook again
+	first := make(chan int)
+	go func() { first <- deliver() }()
+	<-started
+	if code := deliver(); code != http.StatusConflict {
+		t.Errorf("WebhookHandler responded %d to the overlapping delivery, expected %d", code, http.StatusConflict)
+	}
+
+	// The first delivery fails, so the next retry is handled
+	finish <- errors.New("database is down")
+	if code := <-first; code != http.StatusInternalServerError {
+		t.Errorf("WebhookHandler responded %d to the failed delivery, expected %d", code, http.StatusInternalServerError)
+	}
+	if code := deliver(); code != http.StatusOK {
+		t.Errorf("WebhookHandler responded %d to the retry, expected %d", code, http.StatusOK)
+	}
+	if code := deliver(); code != http.StatusOK || calls != 2 {
+		t.Errorf("WebhookHandler responded %d to a duplicate and called the callback %d times, expected %d and 2", code, calls, http.StatusOK)
+	}
+}
+
+func TestWebhookHandlerDedup(t *testing.T) {
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+
+	calls := 0
+	fail := true
+	handler.HandleFunc("orders/create", func(ctx context.Context, d WebhookDelivery) error {
+		calls++
+		if fail {
+			return errors.New("database is down")
+		}
+		return nil
+	})
+
+	req := webhookRequest("hush", "orders/create", `{"id": 1}`)
+	deliver := func(expected int) {
+		r := httptest.NewRequest("POST", "https://example.com/webhooks", strings.NewReader(`{"id": 1}`))
+		r.Header = req.Header
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != expected {
+			t.Errorf("WebhookHandler responded %d, expected %d", w.Code, expected)
+		}
+	}
+
+	// A failed delivery is handled again when it's retried, and only until
+	// it succeeds
+	deliver(http.StatusInternalServerError)
+	fail = false
+	deliver(http.StatusOK)
+	deliver(http.StatusOK)
+
+	if calls != 2 {
+		t.Errorf("WebhookHandler called the callback %d times, expected 2", calls)
+	}
+
+	// Without a store every delivery is handled
+	handler.Store = nil
+	deliver(http.StatusOK)
+	if calls != 3 {
+		t.Errorf("WebhookHandler called the callback %d times, expected 3", calls)
+	}
+}
+
+func TestWebhookHandlerMaxAge(t *testing.T) {
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+	handler.MaxAge = time.Minute
+
+	var triggeredAt time.Time
+	handler.HandleFunc("orders/create", func(ctx context.Context, d WebhookDelivery) error {
+		triggeredAt = d.TriggeredAt
+		return nil
+	})
+
+	now := time.Now().UTC()
+	cases := []struct {
+		header   string
+		expected int
+	}{
+		{now.Add(-10 * time.Second).Format(time.RFC3339Nano), http.StatusOK},
+		{now.Add(-2 * time.Minute).Format(time.RFC3339Nano), http.StatusBadRequest},
+		{"", http.StatusBadRequest},
+		{"yesterday", http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		req := webhookRequest("hush", "orders/create", `{}`)
+		if c.header != "" {
+			req.Header.Set("X-Shopify-Triggered-At", c.header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != c.expected {
+			t.Errorf("WebhookHandler responded %d for triggered at %q, expected %d", w.Code, c.header, c.expected)
+		}
+	}
+
+	if !triggeredAt.Equal(now.Add(-10 * time.Second)) {
+		t.Errorf("WebhookDelivery.TriggeredAt = %v, expected %v", triggeredAt, now.Add(-10*time.Second))
+	}
+}
@@ -0,0 +1,295 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	shopifyTopicHeader      = "X-Shopify-Topic"
+	shopifyShopDomainHeader = "X-Shopify-Shop-Domain"
+	shopifyWebhookIDHeader  = "X-Shopify-Webhook-Id"
+)
+
+// WebhookDelivery is a webhook request sent by Shopify.
+type WebhookDelivery struct {
+	Topic      WebhookTopic
+	ShopDomain string
+	WebhookID  string
+	APIVersion string
+
+	// TriggeredAt is when the event was triggered, or zero if the request
+	// has no X-Shopify-Triggered-At header. It stays the same on retries.
+	TriggeredAt time.Time
+
+	Body []byte
+}
+
+// Decode decodes the JSON body of the delivery into v.
+func (d WebhookDelivery) Decode(v interface{}) error {
+	return json.Unmarshal(d.Body, v)
+}
+
+// Payload decodes the JSON body of the delivery into the payload type of its
+// topic, see WebhookTopic.NewPayload. For example, it returns an *Order for
+// orders/create.
+func (d WebhookDelivery) Payload() (interface{}, error) {
+	payload := d.Topic.NewPayload()
+	if err := d.Decode(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// WebhookHandlerFunc handles a verified webhook delivery. Returning an error
+// makes the WebhookHandler respond with a 500 so that Shopify retries the
+// delivery later.
+type WebhookHandlerFunc func(ctx context.Context, delivery WebhookDelivery) error
+
+// webhookPayloadError occurs when the body of a delivery doesn't decode into
+// the model registered for its topic.
+type webhookPayloadError struct {
+	err error
+}
+
+func (e webhookPayloadError) Error() string {
+	return e.err.Error()
+}
+
+// WebhookHandler is an http.Handler that receives webhooks from Shopify. It
+// verifies the HMAC of every request with the app's secret and dispatches it
+// to the callback registered for its topic, responding with:
+//
+//   - 401 if the HMAC is invalid
+//   - 413 if the body is larger than MaxBodySize
+//   - 400 if a header is missing, the webhook is older than MaxAge or the
+//     body doesn't decode
+//   - 409 if another delivery of the same webhook is still being handled
+//   - 500 if the callback or sink returned an error
+//   - 200 otherwise, also for topics without a callback and duplicates
+//
+// Shopify delivers webhooks at least once. Deliveries with an
+// X-Shopify-Webhook-Id that was already handled successfully are skipped.
+type WebhookHandler struct {
+	// Logger, if set, is used to report rejected deliveries and callback
+	// errors.
+	Logger Logger
+
+	// Store remembers the IDs of handled webhooks. Webhooks are not
+	// deduplicated if it is nil.
+	Store WebhookStore
+
+	// DedupTTL is how long the IDs of processed webhooks are remembered by
+	// the Store.
+	DedupTTL time.Duration
+
+	// ReserveTTL is how long the ID of a webhook stays reserved in the Store
+	// while it is processed. It should be longer than the callbacks take.
+	ReserveTTL time.Duration
+
+	// MaxAge, if set, rejects webhooks that were triggered longer ago, or
+	// that have no X-Shopify-Triggered-At header, to stop replays of old
+	// requests. Shopify keeps the same time when retrying failed deliveries,
+	// so these are rejected as well once they are too old.
+	MaxAge time.Duration
+
+	// MaxBodySize is the size of the largest body accepted, or
+	// DefaultWebhookMaxBodySize if it is 0.
+	MaxBodySize int64
+
+	// Sink, if set, receives the deliveries for topics without a callback.
+	Sink WebhookSink
+
+	app      App
+	mu       sync.RWMutex
+	handlers map[WebhookTopic]WebhookHandlerFunc
+}
+
+// NewWebhookHandler returns a WebhookHandler verifying requests with the
+// ApiSecret of the given app. It deduplicates webhooks with a
+// MemoryWebhookStore of DefaultWebhookStoreSize IDs.
+func NewWebhookHandler(app App) *WebhookHandler {
+	return &WebhookHandler{
+		Store:      NewMemoryWebhookStore(DefaultWebhookStoreSize),
+		DedupTTL:   DefaultWebhookDedupTTL,
+		ReserveTTL: DefaultWebhookReserveTTL,
+		app:        app,
+		handlers:   map[WebhookTopic]WebhookHandlerFunc{},
+	}
+}
+
+// HandleFunc registers the callback for a topic, e.g.
+// WebhookTopicOrdersCreate, replacing any callback registered before.
+func (h *WebhookHandler) HandleFunc(topic WebhookTopic, fn WebhookHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[topic] = fn
+}
+
+// HandleOrder registers a callback for an order topic, e.g. "orders/paid".
+func (h *WebhookHandler) HandleOrder(topic WebhookTopic, fn func(context.Context, WebhookDelivery, *Order) error) {
+	h.HandleFunc(topic, func(ctx context.Context, d WebhookDelivery) error {
+		order := new(Order)
+		if err := d.Decode(order); err != nil {
+			return webhookPayloadError{err}
+		}
+		return fn(ctx, d, order)
+	})
+}
+
+// HandleProduct registers a callback for a product topic, e.g.
+// "products/update".
+func (h *WebhookHandler) HandleProduct(topic WebhookTopic, fn func(context.Context, WebhookDelivery, *Product) error) {
+	h.HandleFunc(topic, func(ctx context.Context, d WebhookDelivery) error {
+		product := new(Product)
+		if err := d.Decode(product); err != nil {
+			return webhookPayloadError{err}
+		}
+		return fn(ctx, d, product)
+	})
+}
+
+// HandleCustomer registers a callback for a customer topic, e.g.
+// "customers/create".
+func (h *WebhookHandler) HandleCustomer(topic WebhookTopic, fn func(context.Context, WebhookDelivery, *Customer) error) {
+	h.HandleFunc(topic, func(ctx context.Context, d WebhookDelivery) error {
+		customer := new(Customer)
+		if err := d.Decode(customer); err != nil {
+			return webhookPayloadError{err}
+		}
+		return fn(ctx, d, customer)
+	})
+}
+
+// HandleFulfillment registers a callback for a fulfillment topic, e.g.
+// "fulfillments/create".
+func (h *WebhookHandler) HandleFulfillment(topic WebhookTopic, fn func(context.Context, WebhookDelivery, *Fulfillment) error) {
+	h.HandleFunc(topic, func(ctx context.Context, d WebhookDelivery) error {
+		fulfillment := new(Fulfillment)
+		if err := d.Decode(fulfillment); err != nil {
+			return webhookPayloadError{err}
+		}
+		return fn(ctx, d, fulfillment)
+	})
+}
+
+// ServeHTTP verifies and dispatches a webhook request.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := h.app.verifyWebhook(r, defaultWebhookMaxBodySize(h.MaxBodySize), nil)
+	switch err {
+	case nil:
+	case ErrWebhookBodyTooLarge:
+		h.reject(w, http.StatusRequestEntityTooLarge, "body of %q webhook is too large", r.Header.Get(shopifyTopicHeader))
+		return
+	case ErrWebhookMissingHMAC, ErrWebhookHMACEncoding, ErrWebhookHMACMismatch:
+		h.reject(w, http.StatusUnauthorized, "invalid hmac for topic %q: %v", r.Header.Get(shopifyTopicHeader), err)
+		return
+	default:
+		h.reject(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	delivery, err := newWebhookDelivery(r.Header, body)
+	if err != nil {
+		h.reject(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if h.MaxAge > 0 && (delivery.TriggeredAt.IsZero() || time.Since(delivery.TriggeredAt) > h.MaxAge) {
+		h.reject(w, http.StatusBadRequest, "%s webhook %s triggered at %q is too old", delivery.Topic, delivery.WebhookID, r.Header.Get(shopifyTriggeredAtHeader))
+		return
+	}
+
+	h.mu.RLock()
+	fn := h.handlers[delivery.Topic]
+	h.mu.RUnlock()
+	if fn == nil && h.Sink != nil {
+		fn = h.Sink.Send
+	}
+
+	if fn != nil {
+		dedup := h.Store != nil && delivery.WebhookID != ""
+		if dedup {
+			status, err := h.Store.Reserve(r.Context(), delivery.WebhookID, h.ReserveTTL)
+			if err != nil {
+				h.reject(w, http.StatusInternalServerError, "storing %s webhook %s: %v", delivery.Topic, delivery.WebhookID, err)
+				return
+			}
+			switch status {
+			case WebhookDone:
+				if h.Logger != nil {
+					h.Logger.Printf("goshopify: skipping duplicate %s webhook %s", delivery.Topic, delivery.WebhookID)
+				}
+				w.WriteHeader(http.StatusOK)
+				return
+			case WebhookInProgress:
+				// The first delivery may still fail, so let Shopify retry
+				h.reject(w, http.StatusConflict, "%s webhook %s is already being handled", delivery.Topic, delivery.WebhookID)
+				return
+			}
+		}
+
+		if err := fn(r.Context(), delivery); err != nil {
+			// Let the redelivery be handled
+			if dedup {
+				h.Store.Release(r.Context(), delivery.WebhookID)
+			}
+			if _, ok := err.(webhookPayloadError); ok {
+				h.reject(w, http.StatusBadRequest, "decoding %s webhook %s: %v", delivery.Topic, delivery.WebhookID, err)
+				return
+			}
+			h.reject(w, http.StatusInternalServerError, "handling %s webhook %s: %v", delivery.Topic, delivery.WebhookID, err)
+			return
+		}
+		if dedup {
+			// The webhook was handled, so acknowledge it even if this fails
+			if err := h.Store.Done(r.Context(), delivery.WebhookID, h.DedupTTL); err != nil && h.Logger != nil {
+				h.Logger.Printf("goshopify: storing %s webhook %s: %v", delivery.Topic, delivery.WebhookID, err)
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// newWebhookDelivery returns the delivery of a webhook body sent with the
+// given headers, which must include the topic and shop domain.
+func newWebhookDelivery(header http.Header, body []byte) (WebhookDelivery, error) {
+	delivery := WebhookDelivery{
+		Topic:      WebhookTopic(header.Get(shopifyTopicHeader)),
+		ShopDomain: header.Get(shopifyShopDomainHeader),
+		WebhookID:  header.Get(shopifyWebhookIDHeader),
+		APIVersion: header.Get(shopifyAPIVersionHeader),
+		Body:       body,
+	}
+	if delivery.Topic == "" || delivery.ShopDomain == "" {
+		return delivery, fmt.Errorf("goshopify: missing %s or %s header", shopifyTopicHeader, shopifyShopDomainHeader)
+	}
+
+	if triggeredAt := header.Get(shopifyTriggeredAtHeader); triggeredAt != "" {
+		var err error
+		delivery.TriggeredAt, err = time.Parse(time.RFC3339Nano, triggeredAt)
+		if err != nil {
+			return delivery, fmt.Errorf("goshopify: invalid %s header %q", shopifyTriggeredAtHeader, triggeredAt)
+		}
+	}
+	return delivery, nil
+}
+
+// reject logs the reason for rejecting a request and responds with the given
+// status.
+func (h *WebhookHandler) reject(w http.ResponseWriter, status int, format string, v ...interface{}) {
+	if h.Logger != nil {
+		h.Logger.Printf("goshopify: webhook rejected: "+format, v...)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
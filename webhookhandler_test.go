@@ -0,0 +1,152 @@
+package goshopify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// webhookTestID is incremented to give each webhook request a unique ID.
+var webhookTestID int
+
+// webhookRequest returns a webhook request for the given topic and body,
+// signed with the given secret.
+func webhookRequest(secret, topic, body string) *http.Request {
+	webhookTestID++
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	req := httptest.NewRequest("POST", "https://example.com/webhooks", strings.NewReader(body))
+	req.Header.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Shopify-Topic", topic)
+	req.Header.Set("X-Shopify-Shop-Domain", "fooshop.myshopify.com")
+	req.Header.Set("X-Shopify-Webhook-Id", fmt.Sprintf("b54557e4-bdd9-4b37-8a5f-%012d", webhookTestID))
+	req.Header.Set("X-Shopify-API-Version", "2019-04")
+	return req
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+
+	var order *Order
+	var delivery WebhookDelivery
+	handler.HandleOrder("orders/create", func(ctx context.Context, d WebhookDelivery, o *Order) error {
+		delivery, order = d, o
+		return nil
+	})
+	handler.HandleProduct("products/create", func(ctx context.Context, d WebhookDelivery, p *Product) error {
+		t.Error("WebhookHandler called the products/create callback for orders/create")
+		return nil
+	})
+
+	req := webhookRequest("hush", "orders/create", `{"id": 123456, "name": "#1001"}`)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("WebhookHandler responded %d, expected %d", w.Code, http.StatusOK)
+	}
+	if order == nil || order.ID != 123456 || order.Name != "#1001" {
+		t.Errorf("WebhookHandler decoded order %+v, expected order 123456", order)
+	}
+
+	expected := WebhookDelivery{
+		Topic:      "orders/create",
+		ShopDomain: "fooshop.myshopify.com",
+		WebhookID:  req.Header.Get("X-Shopify-Webhook-Id"),
+		APIVersion: "2019-04",
+		Body:       []byte(`{"id": 123456, "name": "#1001"}`),
+	}
+	if delivery.Topic != expected.Topic || delivery.ShopDomain != expected.ShopDomain ||
+		delivery.WebhookID != expected.WebhookID || delivery.APIVersion != expected.APIVersion ||
+		string(delivery.Body) != string(expected.Body) {
+		t.Errorf("WebhookHandler delivery = %+v, expected %+v", delivery, expected)
+	}
+}
+
+func TestWebhookHandlerTypedCallbacks(t *testing.T) {
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+
+	ids := map[string]int{}
+	handler.HandleProduct("products/update", func(ctx context.Context, d WebhookDelivery, p *Product) error {
+		ids[string(d.Topic)] = p.ID
+		return nil
+	})
+	handler.HandleCustomer("customers/create", func(ctx context.Context, d WebhookDelivery, c *Customer) error {
+		ids[string(d.Topic)] = c.ID
+		return nil
+	})
+	handler.HandleFulfillment("fulfillments/create", func(ctx context.Context, d WebhookDelivery, f *Fulfillment) error {
+		ids[string(d.Topic)] = f.ID
+		return nil
+	})
+
+	for topic, id := range map[string]int{"products/update": 1, "customers/create": 2, "fulfillments/create": 3} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, webhookRequest("hush", topic, fmt.Sprintf(`{"id": %d}`, id)))
+		if w.Code != http.StatusOK {
+			t.Errorf("WebhookHandler responded %d to %s, expected %d", w.Code, topic, http.StatusOK)
+		}
+		if ids[topic] != id {
+			t.Errorf("WebhookHandler decoded id %d for %s, expected %d", ids[topic], topic, id)
+		}
+	}
+}
+
+func TestWebhookHandlerStatus(t *testing.T) {
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+	handler.HandleOrder("orders/create", func(ctx context.Context, d WebhookDelivery, o *Order) error {
+		return nil
+	})
+	handler.HandleFunc("orders/paid", func(ctx context.Context, d WebhookDelivery) error {
+		return errors.New("database is down")
+	})
+
+	missingTopic := webhookRequest("hush", "orders/create", `{}`)
+	missingTopic.Header.Del("X-Shopify-Topic")
+
+	get := webhookRequest("hush", "orders/create", `{}`)
+	get.Method = "GET"
+
+	cases := []struct {
+		name     string
+		req      *http.Request
+		expected int
+	}{
+		{"valid", webhookRequest("hush", "orders/create", `{"id": 1}`), http.StatusOK},
+		{"unregistered topic", webhookRequest("hush", "shop/update", `{"id": 1}`), http.StatusOK},
+		{"invalid hmac", webhookRequest("wrong", "orders/create", `{"id": 1}`), http.StatusUnauthorized},
+		{"missing topic", missingTopic, http.StatusBadRequest},
+		{"invalid body", webhookRequest("hush", "orders/create", `{"id": "a"}`), http.StatusBadRequest},
+		{"callback error", webhookRequest("hush", "orders/paid", `{"id": 1}`), http.StatusInternalServerError},
+		{"wrong method", get, http.StatusMethodNotAllowed},
+	}
+
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, c.req)
+		if w.Code != c.expected {
+			t.Errorf("WebhookHandler responded %d for %s, expected %d", w.Code, c.name, c.expected)
+		}
+	}
+}
+
+func TestWebhookHandlerLogger(t *testing.T) {
+	logger := &testLogger{}
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+	handler.Logger = logger
+
+	handler.ServeHTTP(httptest.NewRecorder(), webhookRequest("wrong", "orders/create", `{}`))
+
+	if len(logger.lines) != 1 || !strings.Contains(logger.lines[0], "invalid hmac") {
+		t.Errorf("WebhookHandler logged %q, expected an invalid hmac line", logger.lines)
+	}
+}
@@ -0,0 +1,190 @@
+package goshopify
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PresenceMask records which fields were present in a JSON payload. Nested
+// objects have a mask of their own, all other values map to nil.
+//
+// Webhooks subscribed with Webhook.Fields only contain the requested fields,
+// plus metafields if Webhook.MetafieldNamespaces is set. Decoded into a model
+// such as Order, a missing field can't be told from a zero value; the mask can
+// be used to merge only the fields that were sent into a local copy.
+type PresenceMask map[string]PresenceMask
+
+// NewPresenceMask returns a mask with the given fields, in dot notation for
+// nested fields, e.g. "customer.email". It can be used with the Fields of a
+// Webhook to merge all subscribed fields, whether present or not.
+func NewPresenceMask(fields ...string) PresenceMask {
+	m := PresenceMask{}
+	for _, field := range fields {
+		parent := m
+		names := strings.Split(field, ".")
+		for i, name := range names {
+			if i == len(names)-1 {
+				if _, ok := parent[name]; !ok {
+					parent[name] = nil
+				}
+				break
+			}
+			if parent[name] == nil {
+				parent[name] = PresenceMask{}
+			}
+			parent = parent[name]
+		}
+	}
+	return m
+}
+
+// DecodePartial decodes the JSON data into v like json.Unmarshal and returns
+// the fields that were present in it.
+func DecodePartial(data []byte, v interface{}) (PresenceMask, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return newPresenceMask(raw), nil
+}
+
+// DecodePartial decodes the JSON body of the delivery into v and returns the
+// fields that were present in it, see PresenceMask.
+func (d WebhookDelivery) DecodePartial(v interface{}) (PresenceMask, error) {
+	return DecodePartial(d.Body, v)
+}
+
+func newPresenceMask(raw interface{}) PresenceMask {
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	m := make(PresenceMask, len(object))
+	for name, value := range object {
+		m[name] = newPresenceMask(value)
+	}
+	return m
+}
+
+// Has reports whether the field was present, in dot notation for nested
+// fields, e.g. "customer.email".
+func (m PresenceMask) Has(field string) bool {
+	names := strings.Split(field, ".")
+	for i, name := range names {
+		sub, ok := m[name]
+		if !ok {
+			return false
+		}
+		if sub == nil && i < len(names)-1 {
+			return false
+		}
+		m = sub
+	}
+	return true
+}
+
+// Fields returns the names of the present top level fields in alphabetical
+// order.
+func (m PresenceMask) Fields() []string {
+	fields := make([]string, 0, len(m))
+	for name := range m {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Merge copies the fields of src that are in the mask to dst, leaving the
+// others untouched. dst must be a pointer to a struct, src a struct or a
+// pointer to a struct of the same type. Fields are matched by their JSON name
+// and nested structs are merged field by field if the mask has their fields.
+// Slices, maps and other values are copied as a whole.
+func (m PresenceMask) Merge(dst, src interface{}) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return errors.New("goshopify: merge destination must be a non-nil pointer")
+	}
+	sv := reflect.Indirect(reflect.ValueOf(src))
+	if !sv.IsValid() {
+		return errors.New("goshopify: merge source must not be nil")
+	}
+	if sv.Type() != dv.Elem().Type() {
+		return errors.New("goshopify: cannot merge a " + sv.Type().String() + " into a " + dv.Elem().Type().String())
+	}
+	if m == nil {
+		return nil
+	}
+	mergePresent(dv.Elem(), sv, m)
+	return nil
+}
+
+func mergePresent(dst, src reflect.Value, m PresenceMask) {
+	if m == nil {
+		dst.Set(src)
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(src)
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(src.Type().Elem()))
+		}
+		mergePresent(dst.Elem(), src.Elem(), m)
+	case reflect.Struct:
+		mergePresentFields(dst, src, m)
+	default:
+		dst.Set(src)
+	}
+}
+
+func mergePresentFields(dst, src reflect.Value, m PresenceMask) {
+	typ := src.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			// Fields of embedded structs are in the object itself
+			mergePresentFields(dst.Field(i), src.Field(i), m)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if sub, ok := m.lookup(name); ok {
+			mergePresent(dst.Field(i), src.Field(i), sub)
+		}
+	}
+}
+
+// lookup finds a field by name, preferring an exact match but falling back to
+// a case-insensitive one like encoding/json.
+func (m PresenceMask) lookup(name string) (PresenceMask, bool) {
+	if sub, ok := m[name]; ok {
+		return sub, true
+	}
+	for key, sub := range m {
+		if strings.EqualFold(key, name) {
+			return sub, true
+		}
+	}
+	return nil, false
+}
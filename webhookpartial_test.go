@@ -0,0 +1,151 @@
+package goshopify
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodePartial(t *testing.T) {
+	order := Order{}
+	mask, err := DecodePartial([]byte(`{"id":1,"note":"","customer":{"email":"foo@example.com"},"line_items":[{"id":2}],"shipping_address":null}`), &order)
+	if err != nil {
+		t.Fatalf("DecodePartial returned error: %v", err)
+	}
+	if order.ID != 1 || order.Customer == nil || order.Customer.Email != "foo@example.com" {
+		t.Errorf("DecodePartial decoded %+v, expected order 1 with a customer", order)
+	}
+
+	expected := PresenceMask{
+		"id":               nil,
+		"note":             nil,
+		"customer":         PresenceMask{"email": nil},
+		"line_items":       nil,
+		"shipping_address": nil,
+	}
+	if !reflect.DeepEqual(mask, expected) {
+		t.Errorf("DecodePartial returned mask %#v, expected %#v", mask, expected)
+	}
+
+	cases := []struct {
+		field    string
+		expected bool
+	}{
+		{"id", true},
+		{"note", true},
+		{"email", false},
+		{"customer", true},
+		{"customer.email", true},
+		{"customer.first_name", false},
+		{"id.foo", false},
+		{"shipping_address", true},
+		{"shipping_address.city", false},
+	}
+	for _, c := range cases {
+		if actual := mask.Has(c.field); actual != c.expected {
+			t.Errorf("PresenceMask.Has(%q) returned %v, expected %v", c.field, actual, c.expected)
+		}
+	}
+
+	fields := mask.Fields()
+	expectedFields := []string{"customer", "id", "line_items", "note", "shipping_address"}
+	if !reflect.DeepEqual(fields, expectedFields) {
+		t.Errorf("PresenceMask.Fields returned %v, expected %v", fields, expectedFields)
+	}
+}
+
+func TestDecodePartialError(t *testing.T) {
+	order := Order{}
+	if _, err := DecodePartial([]byte(`{"id":"foo"}`), &order); err == nil {
+		t.Error("DecodePartial returned no error for an invalid id")
+	}
+	if _, err := DecodePartial([]byte(`{`), &order); err == nil {
+		t.Error("DecodePartial returned no error for invalid JSON")
+	}
+}
+
+func TestWebhookDeliveryDecodePartial(t *testing.T) {
+	delivery := WebhookDelivery{Topic: WebhookTopicProductsUpdate, Body: []byte(`{"id":1,"title":"Foo"}`)}
+	product := Product{}
+	mask, err := delivery.DecodePartial(&product)
+	if err != nil {
+		t.Fatalf("WebhookDelivery.DecodePartial returned error: %v", err)
+	}
+	if product.Title != "Foo" || !mask.Has("title") || mask.Has("vendor") {
+		t.Errorf("WebhookDelivery.DecodePartial returned %+v, %#v", product, mask)
+	}
+}
+
+func TestPresenceMaskMerge(t *testing.T) {
+	local := Order{
+		ID:              1,
+		Email:           "foo@example.com",
+		Note:            "Gift wrap",
+		Customer:        &Customer{ID: 2, Email: "foo@example.com", FirstName: "Foo"},
+		ShippingAddress: &Address{City: "Ottawa"},
+		LineItems:       []LineItem{{ID: 3}, {ID: 4}},
+	}
+
+	partial := Order{}
+	mask, err := DecodePartial([]byte(`{"id":1,"note":"","customer":{"email":"bar@example.com"},"line_items":[{"id":3}],"shipping_address":null}`), &partial)
+	if err != nil {
+		t.Fatalf("DecodePartial returned error: %v", err)
+	}
+	if err := mask.Merge(&local, partial); err != nil {
+		t.Fatalf("PresenceMask.Merge returned error: %v", err)
+	}
+
+	expected := Order{
+		ID:        1,
+		Email:     "foo@example.com",
+		Customer:  &Customer{ID: 2, Email: "bar@example.com", FirstName: "Foo"},
+		LineItems: []LineItem{{ID: 3}},
+	}
+	if !reflect.DeepEqual(local, expected) {
+		t.Errorf("PresenceMask.Merge returned %+v, expected %+v", local, expected)
+	}
+}
+
+func TestPresenceMaskMergeNewNested(t *testing.T) {
+	local := Order{ID: 1}
+	partial := &Order{Customer: &Customer{Email: "foo@example.com"}}
+	if err := NewPresenceMask("customer.email").Merge(&local, partial); err != nil {
+		t.Fatalf("PresenceMask.Merge returned error: %v", err)
+	}
+
+	expected := Order{ID: 1, Customer: &Customer{Email: "foo@example.com"}}
+	if !reflect.DeepEqual(local, expected) {
+		t.Errorf("PresenceMask.Merge returned %+v, expected %+v", local, expected)
+	}
+}
+
+func TestPresenceMaskMergeErrors(t *testing.T) {
+	mask := NewPresenceMask("id")
+	cases := []struct {
+		dst, src interface{}
+	}{
+		{Order{}, Order{}},
+		{(*Order)(nil), Order{}},
+		{&Order{}, nil},
+		{&Order{}, (*Order)(nil)},
+		{&Order{}, Product{}},
+	}
+	for _, c := range cases {
+		if err := mask.Merge(c.dst, c.src); err == nil {
+			t.Errorf("PresenceMask.Merge(%#v, %#v) returned no error", c.dst, c.src)
+		}
+	}
+}
+
+func TestNewPresenceMask(t *testing.T) {
+	mask := NewPresenceMask("id", "customer.email", "customer.default_address.city", "customer")
+	expected := PresenceMask{
+		"id": nil,
+		"customer": PresenceMask{
+			"email":           nil,
+			"default_address": PresenceMask{"city": nil},
+		},
+	}
+	if !reflect.DeepEqual(mask, expected) {
+		t.Errorf("NewPresenceMask returned %#v, expected %#v", mask, expected)
+	}
+}
@@ -0,0 +1,113 @@
+package goshopify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestWebhookSimulatorNewRequest(t *testing.T) {
+	app := App{ApiSecret: "hush"}
+	simulator := NewWebhookSimulator(app, "fooshop.myshopify.com")
+	simulator.APIVersion = "2019-04"
+
+	req, err := simulator.NewRequest("https://example.com/webhooks", WebhookTopicOrdersCreate, Order{ID: 1})
+	if err != nil {
+		t.Fatalf("WebhookSimulator.NewRequest returned error: %v", err)
+	}
+
+	if !app.VerifyWebhookRequest(req) {
+		t.Error("App.VerifyWebhookRequest() = false for a simulated webhook")
+	}
+
+	headers := map[string]string{
+		"X-Shopify-Topic":       "orders/create",
+		"X-Shopify-Shop-Domain": "fooshop.myshopify.com",
+		"X-Shopify-API-Version": "2019-04",
+		"Content-Type":          "application/json",
+	}
+	for name, expected := range headers {
+		if actual := req.Header.Get(name); actual != expected {
+			t.Errorf("WebhookSimulator.NewRequest header %s = %q, expected %q", name, actual, expected)
+		}
+	}
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if id := req.Header.Get("X-Shopify-Webhook-Id"); !uuid.MatchString(id) {
+		t.Errorf("WebhookSimulator.NewRequest webhook id = %q, expected a UUID", id)
+	}
+
+	triggeredAt, err := time.Parse(time.RFC3339Nano, req.Header.Get("X-Shopify-Triggered-At"))
+	if err != nil || time.Since(triggeredAt) > time.Minute {
+		t.Errorf("WebhookSimulator.NewRequest triggered at = %q, expected now", req.Header.Get("X-Shopify-Triggered-At"))
+	}
+}
+
+func TestWebhookSimulatorPayloads(t *testing.T) {
+	simulator := NewWebhookSimulator(App{ApiSecret: "hush"}, "fooshop.myshopify.com")
+
+	cases := []struct {
+		topic   WebhookTopic
+		payload interface{}
+		err     bool
+	}{
+		{WebhookTopicOrdersCreate, &Order{ID: 1}, false},
+		{WebhookTopicProductsUpdate, Product{ID: 1}, false},
+		{WebhookTopicShopRedact, ShopRedact{ShopID: 1}, false},
+		{WebhookTopicCheckoutsCreate, map[string]interface{}{"id": 1}, false},
+		{WebhookTopicOrdersCreate, []byte(`{"id": 1}`), false},
+		{"foo/bar", []byte(`{"id": 1}`), false},
+		{WebhookTopicOrdersCreate, Product{ID: 1}, true},
+		{WebhookTopicOrdersCreate, nil, true},
+		{"foo/bar", Order{ID: 1}, true},
+	}
+
+	for _, c := range cases {
+		_, err := simulator.NewRequest("https://example.com/webhooks", c.topic, c.payload)
+		if (err != nil) != c.err {
+			t.Errorf("WebhookSimulator.NewRequest(%s, %T) returned error %v, expected error %v", c.topic, c.payload, err, c.err)
+		}
+	}
+}
+
+func TestWebhookSimulatorSend(t *testing.T) {
+	app := App{ApiSecret: "hush"}
+
+	var received *Order
+	handler := NewWebhookHandler(app)
+	handler.HandleOrder(WebhookTopicOrdersPaid, func(ctx context.Context, d WebhookDelivery, order *Order) error {
+		received = order
+		return nil
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	simulator := NewWebhookSimulator(app, "fooshop.myshopify.com")
+	resp, err := simulator.Send(server.URL, WebhookTopicOrdersPaid, Order{ID: 1, Name: "#1001"})
+	if err != nil {
+		t.Fatalf("WebhookSimulator.Send returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("WebhookSimulator.Send got status %d, expected %d", resp.StatusCode, http.StatusOK)
+	}
+	if received == nil || received.ID != 1 || received.Name != "#1001" {
+		t.Errorf("WebhookHandler received %+v, expected order 1", received)
+	}
+
+	// A simulator with the wrong secret is rejected
+	simulator.App.ApiSecret = "wrong"
+	resp, err = simulator.Send(server.URL, WebhookTopicOrdersPaid, Order{ID: 1})
+	if err != nil {
+		t.Fatalf("WebhookSimulator.Send returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("WebhookSimulator.Send got status %d, expected %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,155 @@
+package goshopify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// WebhookSink receives verified webhook deliveries, e.g. to put them on a
+// queue for processing elsewhere. See WebhookHandler.Sink.
+type WebhookSink interface {
+	// Send hands the delivery off. Shopify is only sent an acknowledgement
+	// if it returns nil, otherwise it retries the delivery later.
+	Send(ctx context.Context, delivery WebhookDelivery) error
+}
+
+// NewWebhookSinkHandler returns a WebhookHandler for the app that forwards all
+// deliveries to the sink.
+func NewWebhookSinkHandler(app App, sink WebhookSink) *WebhookHandler {
+	h := NewWebhookHandler(app)
+	h.Sink = sink
+	return h
+}
+
+// webhookDeliveryJSON is the JSON form of a WebhookDelivery.
+type webhookDeliveryJSON struct {
+	Topic       WebhookTopic    `json:"topic"`
+	ShopDomain  string          `json:"shop_domain"`
+	WebhookID   string          `json:"webhook_id,omitempty"`
+	APIVersion  string          `json:"api_version,omitempty"`
+	TriggeredAt *time.Time      `json:"triggered_at,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// MarshalJSON encodes the delivery as an object with its metadata and the
+// body as the payload field.
+func (d WebhookDelivery) MarshalJSON() ([]byte, error) {
+	j := webhookDeliveryJSON{
+		Topic:      d.Topic,
+		ShopDomain: d.ShopDomain,
+		WebhookID:  d.WebhookID,
+		APIVersion: d.APIVersion,
+		Payload:    json.RawMessage(d.Body),
+	}
+	if !d.TriggeredAt.IsZero() {
+		j.TriggeredAt = &d.TriggeredAt
+	}
+	if len(j.Payload) == 0 {
+		j.Payload = json.RawMessage("null")
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes a delivery encoded with MarshalJSON.
+func (d *WebhookDelivery) UnmarshalJSON(b []byte) error {
+	j := webhookDeliveryJSON{}
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	*d = WebhookDelivery{
+		Topic:      j.Topic,
+		ShopDomain: j.ShopDomain,
+		WebhookID:  j.WebhookID,
+		APIVersion: j.APIVersion,
+		Body:       []byte(j.Payload),
+	}
+	if j.TriggeredAt != nil {
+		d.TriggeredAt = *j.TriggeredAt
+	}
+	return nil
+}
+
+// ChannelWebhookSink is a WebhookSink that sends deliveries over a channel.
+// A delivery is acknowledged once the channel accepted it, so use a buffered
+// channel to acknowledge deliveries before they are processed.
+type ChannelWebhookSink struct {
+	deliveries chan WebhookDelivery
+}
+
+// NewChannelWebhookSink returns a ChannelWebhookSink with a channel buffering
+// the given number of deliveries.
+func NewChannelWebhookSink(buffer int) *ChannelWebhookSink {
+	return &ChannelWebhookSink{deliveries: make(chan WebhookDelivery, buffer)}
+}
+
+// Deliveries returns the channel the deliveries are sent over.
+func (s *ChannelWebhookSink) Deliveries() <-chan WebhookDelivery {
+	return s.deliveries
+}
+
+// Send waits until the channel accepts the delivery or ctx is done.
+func (s *ChannelWebhookSink) Send(ctx context.Context, delivery WebhookDelivery) error {
+	select {
+	case s.deliveries <- delivery:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// JSONLWebhookSink is a WebhookSink that writes deliveries to a JSONL file,
+// one JSON encoded WebhookDelivery per line. It is safe for concurrent use.
+type JSONLWebhookSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewJSONLWebhookSink returns a JSONLWebhookSink writing to w.
+func NewJSONLWebhookSink(w io.Writer) *JSONLWebhookSink {
+	return &JSONLWebhookSink{w: w}
+}
+
+// OpenJSONLWebhookSink returns a JSONLWebhookSink appending to the file at
+// the given path, which is created if it doesn't exist.
+func OpenJSONLWebhookSink(path string) (*JSONLWebhookSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLWebhookSink{w: f, closer: f}, nil
+}
+
+// Send writes the delivery as a line. If the writer is a file, the delivery
+// is only acknowledged once the file is synced to disk.
+func (s *JSONLWebhookSink) Send(ctx context.Context, delivery WebhookDelivery) error {
+	line, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write(line); err != nil {
+		return err
+	}
+	if f, ok := s.w.(*os.File); ok {
+		return f.Sync()
+	}
+	return nil
+}
+
+// Close closes the file opened by OpenJSONLWebhookSink. It does nothing for
+// sinks created with NewJSONLWebhookSink.
+func (s *JSONLWebhookSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
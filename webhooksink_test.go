@@ -0,0 +1,139 @@
+package goshopify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhookSinkHandler(t *testing.T) {
+	app := App{ApiSecret: "hush"}
+	sink := NewChannelWebhookSink(1)
+	handler := NewWebhookSinkHandler(app, sink)
+
+	simulator := NewWebhookSimulator(app, "fooshop.myshopify.com")
+	simulator.APIVersion = "2019-04"
+	req, _ := simulator.NewRequest("https://example.com/webhooks", WebhookTopicOrdersCreate, Order{ID: 1})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("WebhookHandler responded %d, expected %d", w.Code, http.StatusOK)
+	}
+
+	select {
+	case delivery := <-sink.Deliveries():
+		if delivery.Topic != WebhookTopicOrdersCreate || delivery.ShopDomain != "fooshop.myshopify.com" ||
+			delivery.APIVersion != "2019-04" || delivery.WebhookID != req.Header.Get("X-Shopify-Webhook-Id") {
+			t.Errorf("ChannelWebhookSink received %+v, expected the simulated delivery", delivery)
+		}
+		order := Order{}
+		if err := delivery.Decode(&order); err != nil || order.ID != 1 {
+			t.Errorf("ChannelWebhookSink received order %+v, %v, expected order 1", order, err)
+		}
+	default:
+		t.Error("ChannelWebhookSink received no delivery")
+	}
+}
+
+func TestWebhookSinkHandlerNotAcknowledged(t *testing.T) {
+	app := App{ApiSecret: "hush"}
+	sink := NewChannelWebhookSink(0)
+	handler := NewWebhookSinkHandler(app, sink)
+
+	simulator := NewWebhookSimulator(app, "fooshop.myshopify.com")
+	req, _ := simulator.NewRequest("https://example.com/webhooks", WebhookTopicOrdersCreate, Order{ID: 1})
+
+	// Nobody reads the channel so the delivery times out
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req.WithContext(ctx))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("WebhookHandler responded %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+
+	// The retry is delivered
+	go func() { <-sink.Deliveries() }()
+	req, _ = simulator.NewRequest("https://example.com/webhooks", WebhookTopicOrdersCreate, Order{ID: 1})
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("WebhookHandler responded %d, expected %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestJSONLWebhookSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+	sink := NewJSONLWebhookSink(buf)
+
+	deliveries := []WebhookDelivery{
+		{
+			Topic:       WebhookTopicOrdersCreate,
+			ShopDomain:  "fooshop.myshopify.com",
+			WebhookID:   "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+			APIVersion:  "2019-04",
+			TriggeredAt: time.Date(2019, time.April, 1, 12, 0, 0, 0, time.UTC),
+			Body:        []byte(`{"id":1}`),
+		},
+		{Topic: WebhookTopicShopRedact, ShopDomain: "fooshop.myshopify.com", Body: []byte(`{"shop_id":1}`)},
+	}
+	for _, delivery := range deliveries {
+		if err := sink.Send(context.Background(), delivery); err != nil {
+			t.Fatalf("JSONLWebhookSink.Send returned error: %v", err)
+		}
+	}
+
+	var actual []WebhookDelivery
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		delivery := WebhookDelivery{}
+		if err := json.Unmarshal(scanner.Bytes(), &delivery); err != nil {
+			t.Fatalf("Decoding line %q returned error: %v", scanner.Text(), err)
+		}
+		actual = append(actual, delivery)
+	}
+
+	if !reflect.DeepEqual(actual, deliveries) {
+		t.Errorf("JSONLWebhookSink wrote %+v, expected %+v", actual, deliveries)
+	}
+}
+
+func TestOpenJSONLWebhookSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goshopify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "webhooks.jsonl")
+	for i := 0; i < 2; i++ {
+		sink, err := OpenJSONLWebhookSink(path)
+		if err != nil {
+			t.Fatalf("OpenJSONLWebhookSink returned error: %v", err)
+		}
+		delivery := WebhookDelivery{Topic: WebhookTopicOrdersCreate, ShopDomain: "fooshop.myshopify.com", Body: []byte(`{}`)}
+		if err := sink.Send(context.Background(), delivery); err != nil {
+			t.Errorf("JSONLWebhookSink.Send returned error: %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Errorf("JSONLWebhookSink.Close returned error: %v", err)
+		}
+	}
+
+	b, _ := ioutil.ReadFile(path)
+	expected := `{"topic":"orders/create","shop_domain":"fooshop.myshopify.com","payload":{}}` + "\n"
+	if string(b) != expected+expected {
+		t.Errorf("OpenJSONLWebhookSink wrote %q, expected the file to be appended to", b)
+	}
+}
@@ -0,0 +1,314 @@
+package goshopify
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// WebhookTopic is the topic of a webhook, i.e. the event it is sent for.
+// See: https://help.shopify.com/api/reference/webhook
+type WebhookTopic string
+
+// Webhook topics of the Admin API
+const (
+	WebhookTopicAppUninstalled                                  WebhookTopic = "app/uninstalled"
+	WebhookTopicAppPurchasesOneTimeUpdate                       WebhookTopic = "app_purchases_one_time/update"
+	WebhookTopicAppSubscriptionsApproachingCappedAmount         WebhookTopic = "app_subscriptions/approaching_capped_amount"
+	WebhookTopicAppSubscriptionsUpdate                          WebhookTopic = "app_subscriptions/update"
+	WebhookTopicBulkOperationsFinish                            WebhookTopic = "bulk_operations/finish"
+	WebhookTopicCartsCreate                                     WebhookTopic = "carts/create"
+	WebhookTopicCartsUpdate                                     WebhookTopic = "carts/update"
+	WebhookTopicCheckoutsCreate                                 WebhookTopic = "checkouts/create"
+	WebhookTopicCheckoutsDelete                                 WebhookTopic = "checkouts/delete"
+	WebhookTopicCheckoutsUpdate                                 WebhookTopic = "checkouts/update"
+	WebhookTopicCollectionListingsAdd                           WebhookTopic = "collection_listings/add"
+	WebhookTopicCollectionListingsRemove                        WebhookTopic = "collection_listings/remove"
+	WebhookTopicCollectionListingsUpdate                        WebhookTopic = "collection_listings/update"
+	WebhookTopicCollectionPublicationsCreate                    WebhookTopic = "collection_publications/create"
+	WebhookTopicCollectionPublicationsDelete                    WebhookTopic = "collection_publications/delete"
+	WebhookTopicCollectionPublicationsUpdate                    WebhookTopic = "collection_publications/update"
+	WebhookTopicCollectionsCreate                               WebhookTopic = "collections/create"
+	WebhookTopicCollectionsDelete                               WebhookTopic = "collections/delete"
+	WebhookTopicCollectionsUpdate                               WebhookTopic = "collections/update"
+	WebhookTopicCompaniesCreate                                 WebhookTopic = "companies/create"
+	WebhookTopicCompaniesDelete                                 WebhookTopic = "companies/delete"
+	WebhookTopicCompaniesUpdate                                 WebhookTopic = "companies/update"
+	WebhookTopicCustomerGroupsCreate                            WebhookTopic = "customer_groups/create"
+	WebhookTopicCustomerGroupsDelete                            WebhookTopic = "customer_groups/delete"
+	WebhookTopicCustomerGroupsUpdate                            WebhookTopic = "customer_groups/update"
+	WebhookTopicCustomerPaymentMethodsCreate                    WebhookTopic = "customer_payment_methods/create"
+	WebhookTopicCustomerPaymentMethodsRevoke                    WebhookTopic = "customer_payment_methods/revoke"
+	WebhookTopicCustomerPaymentMethodsUpdate                    WebhookTopic = "customer_payment_methods/update"
+	WebhookTopicCustomersCreate                                 WebhookTopic = "customers/create"
+	WebhookTopicCustomersDataRequest                            WebhookTopic = "customers/data_request"
+	WebhookTopicCustomersDelete                                 WebhookTopic = "customers/delete"
+	WebhookTopicCustomersDisable                                WebhookTopic = "customers/disable"
+	WebhookTopicCustomersEnable                                 WebhookTopic = "customers/enable"
+	WebhookTopicCustomersRedact                                 WebhookTopic = "customers/redact"
+	WebhookTopicCustomersUpdate                                 WebhookTopic = "customers/update"
+	WebhookTopicCustomersEmailMarketingConsentUpdate            WebhookTopic = "customers_email_marketing_consent/update"
+	WebhookTopicCustomersMarketingConsentUpdate                 WebhookTopic = "customers_marketing_consent/update"
+	WebhookTopicDisputesCreate                                  WebhookTopic = "disputes/create"
+	WebhookTopicDisputesUpdate                                  WebhookTopic = "disputes/update"
+	WebhookTopicDomainsCreate                                   WebhookTopic = "domains/create"
+	WebhookTopicDomainsDestroy                                  WebhookTopic = "domains/destroy"
+	WebhookTopicDomainsUpdate                                   WebhookTopic = "domains/update"
+	WebhookTopicDraftOrdersCreate                               WebhookTopic = "draft_orders/create"
+	WebhookTopicDraftOrdersDelete                               WebhookTopic = "draft_orders/delete"
+	WebhookTopicDraftOrdersUpdate                               WebhookTopic = "draft_orders/update"
+	WebhookTopicFulfillmentEventsCreate                         WebhookTopic = "fulfillment_events/create"
+	WebhookTopicFulfillmentEventsDelete                         WebhookTopic = "fulfillment_events/delete"
+	WebhookTopicFulfillmentOrdersCancelled                      WebhookTopic = "fulfillment_orders/cancelled"
+	WebhookTopicFulfillmentOrdersHoldReleased                   WebhookTopic = "fulfillment_orders/hold_released"
+	WebhookTopicFulfillmentOrdersMoved                          WebhookTopic = "fulfillment_orders/moved"
+	WebhookTopicFulfillmentOrdersOrderRoutingComplete           WebhookTopic = "fulfillment_orders/order_routing_complete"
+	WebhookTopicFulfillmentOrdersPlacedOnHold                   WebhookTopic = "fulfillment_orders/placed_on_hold"
+	WebhookTopicFulfillmentOrdersRescheduled                    WebhookTopic = "fulfillment_orders/rescheduled"
+	WebhookTopicFulfillmentOrdersScheduledFulfillmentOrderReady WebhookTopic = "fulfillment_orders/scheduled_fulfillment_order_ready"
+	WebhookTopicFulfillmentsCreate                              WebhookTopic = "fulfillments/create"
+	WebhookTopicFulfillmentsUpdate                              WebhookTopic = "fulfillments/update"
+	WebhookTopicInventoryItemsCreate                            WebhookTopic = "inventory_items/create"
+	WebhookTopicInventoryItemsDelete                            WebhookTopic = "inventory_items/delete"
+	WebhookTopicInventoryItemsUpdate                            WebhookTopic = "inventory_items/update"
+	WebhookTopicInventoryLevelsConnect                          WebhookTopic = "inventory_levels/connect"
+	WebhookTopicInventoryLevelsDisconnect                       WebhookTopic = "inventory_levels/disconnect"
+	WebhookTopicInventoryLevelsUpdate                           WebhookTopic = "inventory_levels/update"
+	WebhookTopicLocalesCreate                                   WebhookTopic = "locales/create"
+	WebhookTopicLocalesUpdate                                   WebhookTopic = "locales/update"
+	WebhookTopicLocationsCreate                                 WebhookTopic = "locations/create"
+	WebhookTopicLocationsDelete                                 WebhookTopic = "locations/delete"
+	WebhookTopicLocationsUpdate                                 WebhookTopic = "locations/update"
+	WebhookTopicMarketsCreate                                   WebhookTopic = "markets/create"
+	WebhookTopicMarketsDelete                                   WebhookTopic = "markets/delete"
+	WebhookTopicMarketsUpdate                                   WebhookTopic = "markets/update"
+	WebhookTopicOrderTransactionsCreate                         WebhookTopic = "order_transactions/create"
+	WebhookTopicOrdersCancelled                                 WebhookTopic = "orders/cancelled"
+	WebhookTopicOrdersCreate                                    WebhookTopic = "orders/create"
+	WebhookTopicOrdersDelete                                    WebhookTopic = "orders/delete"
+	WebhookTopicOrdersEdited                                    WebhookTopic = "orders/edited"
+	WebhookTopicOrdersFulfilled                                 WebhookTopic = "orders/fulfilled"
+	WebhookTopicOrdersPaid                                      WebhookTopic = "orders/paid"
+	WebhookTopicOrdersPartiallyFulfilled                        WebhookTopic = "orders/partially_fulfilled"
+	WebhookTopicOrdersUpdated                                   WebhookTopic = "orders/updated"
+	WebhookTopicPaymentTermsCreate                              WebhookTopic = "payment_terms/create"
+	WebhookTopicPaymentTermsDelete                              WebhookTopic = "payment_terms/delete"
+	WebhookTopicPaymentTermsUpdate                              WebhookTopic = "payment_terms/update"
+	WebhookTopicProductListingsAdd                              WebhookTopic = "product_listings/add"
+	WebhookTopicProductListingsRemove                           WebhookTopic = "product_listings/remove"
+	WebhookTopicProductListingsUpdate                           WebhookTopic = "product_listings/update"
+	WebhookTopicProductPublicationsCreate                       WebhookTopic = "product_publications/create"
+	WebhookTopicProductPublicationsDelete                       WebhookTopic = "product_publications/delete"
+	WebhookTopicProductPublicationsUpdate                       WebhookTopic = "product_publications/update"
+	WebhookTopicProductsCreate                                  WebhookTopic = "products/create"
+	WebhookTopicProductsDelete                                  WebhookTopic = "products/delete"
+	WebhookTopicProductsUpdate                                  WebhookTopic = "products/update"
+	WebhookTopicProfilesCreate                                  WebhookTopic = "profiles/create"
+	WebhookTopicProfilesDelete                                  WebhookTopic = "profiles/delete"
+	WebhookTopicProfilesUpdate                                  WebhookTopic = "profiles/update"
+	WebhookTopicRefundsCreate                                   WebhookTopic = "refunds/create"
+	WebhookTopicSellingPlanGroupsCreate                         WebhookTopic = "selling_plan_groups/create"
+	WebhookTopicSellingPlanGroupsDelete                         WebhookTopic = "selling_plan_groups/delete"
+	WebhookTopicSellingPlanGroupsUpdate                         WebhookTopic = "selling_plan_groups/update"
+	WebhookTopicShopRedact                                      WebhookTopic = "shop/redact"
+	WebhookTopicShopUpdate                                      WebhookTopic = "shop/update"
+	WebhookTopicSubscriptionBillingAttemptsChallenged           WebhookTopic = "subscription_billing_attempts/challenged"
+	WebhookTopicSubscriptionBillingAttemptsFailure              WebhookTopic = "subscription_billing_attempts/failure"
+	WebhookTopicSubscriptionBillingAttemptsSuccess              WebhookTopic = "subscription_billing_attempts/success"
+	WebhookTopicSubscriptionContractsCreate                     WebhookTopic = "subscription_contracts/create"
+	WebhookTopicSubscriptionContractsUpdate                     WebhookTopic = "subscription_contracts/update"
+	WebhookTopicTenderTransactionsCreate                        WebhookTopic = "tender_transactions/create"
+	WebhookTopicThemesCreate                                    WebhookTopic = "themes/create"
+	WebhookTopicThemesDelete                                    WebhookTopic = "themes/delete"
+	WebhookTopicThemesPublish                                   WebhookTopic = "themes/publish"
+	WebhookTopicThemesUpdate                                    WebhookTopic = "themes/update"
+)
+
+var (
+	orderPayload       = reflect.TypeOf(Order{})
+	productPayload     = reflect.TypeOf(Product{})
+	customerPayload    = reflect.TypeOf(Customer{})
+	fulfillmentPayload = reflect.TypeOf(Fulfillment{})
+	shopPayload        = reflect.TypeOf(Shop{})
+	themePayload       = reflect.TypeOf(Theme{})
+)
+
+// webhookTopicPayloads maps the known topics to the type of their payload.
+// Topics without a model in this package map to nil and their payloads are
+// decoded generically.
+var (
+	webhookTopicsMu      sync.RWMutex
+	webhookTopicPayloads = map[WebhookTopic]reflect.Type{
+		WebhookTopicAppUninstalled:                                  shopPayload,
+		WebhookTopicAppPurchasesOneTimeUpdate:                       nil,
+		WebhookTopicAppSubscriptionsApproachingCappedAmount:         nil,
+		WebhookTopicAppSubscriptionsUpdate:                          nil,
+		WebhookTopicBulkOperationsFinish:                            nil,
+		WebhookTopicCartsCreate:                                     nil,
+		WebhookTopicCartsUpdate:                                     nil,
+		WebhookTopicCheckoutsCreate:                                 nil,
+		WebhookTopicCheckoutsDelete:                                 nil,
+		WebhookTopicCheckoutsUpdate:                                 nil,
+		WebhookTopicCollectionListingsAdd:                           nil,
+		WebhookTopicCollectionListingsRemove:                        nil,
+		WebhookTopicCollectionListingsUpdate:                        nil,
+		WebhookTopicCollectionPublicationsCreate:                    nil,
+		WebhookTopicCollectionPublicationsDelete:                    nil,
+		WebhookTopicCollectionPublicationsUpdate:                    nil,
+		WebhookTopicCollectionsCreate:                               nil,
+		WebhookTopicCollectionsDelete:                               nil,
+		WebhookTopicCollectionsUpdate:                               nil,
+		WebhookTopicCompaniesCreate:                                 nil,
+		WebhookTopicCompaniesDelete:                                 nil,
+		WebhookTopicCompaniesUpdate:                                 nil,
+		WebhookTopicCustomerGroupsCreate:                            nil,
+		WebhookTopicCustomerGroupsDelete:                            nil,
+		WebhookTopicCustomerGroupsUpdate:                            nil,
+		WebhookTopicCustomerPaymentMethodsCreate:                    nil,
+		WebhookTopicCustomerPaymentMethodsRevoke:                    nil,
+		WebhookTopicCustomerPaymentMethodsUpdate:                    nil,
+		WebhookTopicCustomersCreate:                                 customerPayload,
+		WebhookTopicCustomersDataRequest:                            reflect.TypeOf(CustomersDataRequest{}),
+		WebhookTopicCustomersDelete:                                 customerPayload,
+		WebhookTopicCustomersDisable:                                customerPayload,
+		WebhookTopicCustomersEnable:                                 customerPayload,
+		WebhookTopicCustomersRedact:                                 reflect.TypeOf(CustomersRedact{}),
+		WebhookTopicCustomersUpdate:                                 customerPayload,
+		WebhookTopicCustomersEmailMarketingConsentUpdate:            nil,
+		WebhookTopicCustomersMarketingConsentUpdate:                 nil,
+		WebhookTopicDisputesCreate:                                  nil,
+		WebhookTopicDisputesUpdate:                                  nil,
+		WebhookTopicDomainsCreate:                                   nil,
+		WebhookTopicDomainsDestroy:                                  nil,
+		WebhookTopicDomainsUpdate:                                   nil,
+		WebhookTopicDraftOrdersCreate:                               nil,
+		WebhookTopicDraftOrdersDelete:                               nil,
+		WebhookTopicDraftOrdersUpdate:                               nil,
+		WebhookTopicFulfillmentEventsCreate:                         nil,
+		WebhookTopicFulfillmentEventsDelete:                         nil,
+		WebhookTopicFulfillmentOrdersCancelled:                      nil,
+		WebhookTopicFulfillmentOrdersHoldReleased:                   nil,
+		WebhookTopicFulfillmentOrdersMoved:                          nil,
+		WebhookTopicFulfillmentOrdersOrderRoutingComplete:           nil,
+		WebhookTopicFulfillmentOrdersPlacedOnHold:                   nil,
+		WebhookTopicFulfillmentOrdersRescheduled:                    nil,
+		WebhookTopicFulfillmentOrdersScheduledFulfillmentOrderReady: nil,
+		WebhookTopicFulfillmentsCreate:                              fulfillmentPayload,
+		WebhookTopicFulfillmentsUpdate:                              fulfillmentPayload,
+		WebhookTopicInventoryItemsCreate:                            nil,
+		WebhookTopicInventoryItemsDelete:                            nil,
+		WebhookTopicInventoryItemsUpdate:                            nil,
+		WebhookTopicInventoryLevelsConnect:                          nil,
+		WebhookTopicInventoryLevelsDisconnect:                       nil,
+		WebhookTopicInventoryLevelsUpdate:                           nil,
+		WebhookTopicLocalesCreate:                                   nil,
+		WebhookTopicLocalesUpdate:                                   nil,
+		WebhookTopicLocationsCreate:                                 nil,
+		WebhookTopicLocationsDelete:                                 nil,
+		WebhookTopicLocationsUpdate:                                 nil,
+		WebhookTopicMarketsCreate:                                   nil,
+		WebhookTopicMarketsDelete:                                   nil,
+		WebhookTopicMarketsUpdate:                                   nil,
+		WebhookTopicOrderTransactionsCreate:                         reflect.TypeOf(Transaction{}),
+		WebhookTopicOrdersCancelled:                                 orderPayload,
+		WebhookTopicOrdersCreate:                                    orderPayload,
+		WebhookTopicOrdersDelete:                                    orderPayload,
+		WebhookTopicOrdersEdited:                                    nil,
+		WebhookTopicOrdersFulfilled:                                 orderPayload,
+		WebhookTopicOrdersPaid:                                      orderPayload,
+		WebhookTopicOrdersPartiallyFulfilled:                        orderPayload,
+		WebhookTopicOrdersUpdated:                                   orderPayload,
+		WebhookTopicPaymentTermsCreate:                              nil,
+		WebhookTopicPaymentTermsDelete:                              nil,
+		WebhookTopicPaymentTermsUpdate:                              nil,
+		WebhookTopicProductListingsAdd:                              nil,
+		WebhookTopicProductListingsRemove:                           nil,
+		WebhookTopicProductListingsUpdate:                           nil,
+		WebhookTopicProductPublicationsCreate:                       nil,
+		WebhookTopicProductPublicationsDelete:                       nil,
+		WebhookTopicProductPublicationsUpdate:                       nil,
+		WebhookTopicProductsCreate:                                  productPayload,
+		WebhookTopicProductsDelete:                                  productPayload,
+		WebhookTopicProductsUpdate:                                  productPayload,
+		WebhookTopicProfilesCreate:                                  nil,
+		WebhookTopicProfilesDelete:                                  nil,
+		WebhookTopicProfilesUpdate:                                  nil,
+		WebhookTopicRefundsCreate:                                   reflect.TypeOf(Refund{}),
+		WebhookTopicSellingPlanGroupsCreate:                         nil,
+		WebhookTopicSellingPlanGroupsDelete:                         nil,
+		WebhookTopicSellingPlanGroupsUpdate:                         nil,
+		WebhookTopicShopRedact:                                      reflect.TypeOf(ShopRedact{}),
+		WebhookTopicShopUpdate:                                      shopPayload,
+		WebhookTopicSubscriptionBillingAttemptsChallenged:           nil,
+		WebhookTopicSubscriptionBillingAttemptsFailure:              nil,
+		WebhookTopicSubscriptionBillingAttemptsSuccess:              nil,
+		WebhookTopicSubscriptionContractsCreate:                     nil,
+		WebhookTopicSubscriptionContractsUpdate:                     nil,
+		WebhookTopicTenderTransactionsCreate:                        nil,
+		WebhookTopicThemesCreate:                                    themePayload,
+		WebhookTopicThemesDelete:                                    themePayload,
+		WebhookTopicThemesPublish:                                   themePayload,
+		WebhookTopicThemesUpdate:                                    themePayload,
+	}
+)
+
+// RegisterWebhookTopic adds a topic to the known topics, or changes the
+// payload type of a known topic. The payload is a value of the type to decode
+// the payloads of the topic into, e.g. Order{}, or nil to decode them
+// generically. Use it for topics that were added to the Admin API after this
+// package.
+func RegisterWebhookTopic(topic WebhookTopic, payload interface{}) {
+	webhookTopicsMu.Lock()
+	defer webhookTopicsMu.Unlock()
+
+	var typ reflect.Type
+	if payload != nil {
+		typ = reflect.TypeOf(payload)
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+	}
+	webhookTopicPayloads[topic] = typ
+}
+
+// WebhookTopics returns all known topics in alphabetical order.
+func WebhookTopics() []WebhookTopic {
+	webhookTopicsMu.RLock()
+	defer webhookTopicsMu.RUnlock()
+
+	topics := make([]WebhookTopic, 0, len(webhookTopicPayloads))
+	for topic := range webhookTopicPayloads {
+		topics = append(topics, topic)
+	}
+	sort.Sort(webhookTopicsByName(topics))
+	return topics
+}
+
+type webhookTopicsByName []WebhookTopic
+
+func (t webhookTopicsByName) Len() int           { return len(t) }
+func (t webhookTopicsByName) Less(i, j int) bool { return t[i] < t[j] }
+func (t webhookTopicsByName) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+
+// Valid reports whether the topic is known.
+func (t WebhookTopic) Valid() bool {
+	webhookTopicsMu.RLock()
+	defer webhookTopicsMu.RUnlock()
+
+	_, ok := webhookTopicPayloads[t]
+	return ok
+}
+
+// NewPayload returns a pointer to a new value of the payload type of the
+// topic, e.g. *Order for orders/create, to decode a payload into. It returns
+// a *map[string]interface{} for topics without a payload type.
+func (t WebhookTopic) NewPayload() interface{} {
+	webhookTopicsMu.RLock()
+	typ := webhookTopicPayloads[t]
+	webhookTopicsMu.RUnlock()
+
+	if typ == nil {
+		return new(map[string]interface{})
+	}
+	return reflect.New(typ).Interface()
+}
@@ -0,0 +1,97 @@
+package goshopify
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWebhookTopicNewPayload(t *testing.T) {
+	cases := []struct {
+		topic    WebhookTopic
+		expected interface{}
+	}{
+		{WebhookTopicOrdersCreate, &Order{}},
+		{WebhookTopicProductsUpdate, &Product{}},
+		{WebhookTopicAppUninstalled, &Shop{}},
+		{WebhookTopicCustomersRedact, &CustomersRedact{}},
+		{WebhookTopicShopRedact, &ShopRedact{}},
+		{WebhookTopicCheckoutsCreate, new(map[string]interface{})},
+		{"foo/bar", new(map[string]interface{})},
+	}
+
+	for _, c := range cases {
+		actual := c.topic.NewPayload()
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("WebhookTopic(%q).NewPayload() = %T, expected %T", c.topic, actual, c.expected)
+		}
+	}
+}
+
+func TestWebhookTopicValid(t *testing.T) {
+	for _, topic := range []WebhookTopic{WebhookTopicOrdersPaid, WebhookTopicOrdersEdited, WebhookTopicBulkOperationsFinish, WebhookTopicAppSubscriptionsUpdate} {
+		if !topic.Valid() {
+			t.Errorf("WebhookTopic(%q).Valid() = false, expected true", topic)
+		}
+	}
+	if WebhookTopic("orders/foo").Valid() {
+		t.Error("WebhookTopic(\"orders/foo\").Valid() = true, expected false")
+	}
+}
+
+func TestRegisterWebhookTopic(t *testing.T) {
+	topic := WebhookTopic("variants/create")
+	defer func() {
+		webhookTopicsMu.Lock()
+		delete(webhookTopicPayloads, topic)
+		webhookTopicsMu.Unlock()
+	}()
+
+	RegisterWebhookTopic(topic, &Variant{})
+
+	if !topic.Valid() {
+		t.Errorf("WebhookTopic(%q).Valid() = false after registering", topic)
+	}
+	if _, ok := topic.NewPayload().(*Variant); !ok {
+		t.Errorf("WebhookTopic(%q).NewPayload() = %T, expected *Variant", topic, topic.NewPayload())
+	}
+}
+
+func TestWebhookTopics(t *testing.T) {
+	topics := WebhookTopics()
+	if len(topics) != len(webhookTopicPayloads) {
+		t.Errorf("WebhookTopics() returned %d topics, expected %d", len(topics), len(webhookTopicPayloads))
+	}
+	if !sort.IsSorted(webhookTopicsByName(topics)) {
+		t.Errorf("WebhookTopics() = %v, expected sorted topics", topics)
+	}
+}
+
+func TestWebhookDeliveryPayload(t *testing.T) {
+	delivery := WebhookDelivery{
+		Topic: WebhookTopicCustomersDataRequest,
+		Body: []byte(`{
+			"shop_id": 954889,
+			"shop_domain": "snowdevil.myshopify.com",
+			"orders_requested": [299938, 280263],
+			"customer": {"id": 191167, "email": "john@email.com", "phone": "555-625-1199"},
+			"data_request": {"id": 9999}
+		}`),
+	}
+
+	payload, err := delivery.Payload()
+	if err != nil {
+		t.Fatalf("WebhookDelivery.Payload() returned error: %v", err)
+	}
+
+	expected := &CustomersDataRequest{
+		ShopID:          954889,
+		ShopDomain:      "snowdevil.myshopify.com",
+		OrdersRequested: []int{299938, 280263},
+		Customer:        GDPRCustomer{ID: 191167, Email: "john@email.com", Phone: "555-625-1199"},
+		DataRequest:     GDPRDataRequest{ID: 9999},
+	}
+	if !reflect.DeepEqual(payload, expected) {
+		t.Errorf("WebhookDelivery.Payload() = %+v, expected %+v", payload, expected)
+	}
+}
@@ -0,0 +1,114 @@
+package goshopify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultWebhookMaxBodySize is the largest webhook body accepted by
+// VerifyWebhook and DecodeWebhook when no size is given.
+const DefaultWebhookMaxBodySize = 10 << 20
+
+// Errors returned when verifying a webhook request.
+var (
+	ErrWebhookMissingHMAC  = errors.New("goshopify: missing " + shopifyChecksumHeader + " header")
+	ErrWebhookHMACEncoding = errors.New("goshopify: " + shopifyChecksumHeader + " header is not base64")
+	ErrWebhookHMACMismatch = errors.New("goshopify: webhook hmac mismatch")
+	ErrWebhookBodyTooLarge = errors.New("goshopify: webhook body too large")
+)
+
+// WebhookReadError occurs when the body of a webhook request could not be
+// read.
+type WebhookReadError struct {
+	Err error
+}
+
+func (e WebhookReadError) Error() string {
+	return "goshopify: reading webhook body: " + e.Err.Error()
+}
+
+// VerifyWebhook verifies the HMAC of a webhook request, sent by Shopify. The
+// body is read through the HMAC once and is still readable afterwards. At
+// most maxBodySize bytes are read, or DefaultWebhookMaxBodySize if it is 0.
+//
+// The returned error is one of ErrWebhookMissingHMAC, ErrWebhookHMACEncoding,
+// ErrWebhookHMACMismatch, ErrWebhookBodyTooLarge or a WebhookReadError.
+func (app App) VerifyWebhook(r *http.Request, maxBodySize int64) error {
+	_, err := app.verifyWebhook(r, defaultWebhookMaxBodySize(maxBodySize), nil)
+	return err
+}
+
+// DecodeWebhook is like VerifyWebhook but also decodes the JSON body into v
+// while it is read. A body that doesn't decode results in a
+// ResponseDecodingError once the HMAC is verified. v must not be used if an
+// error is returned.
+func (app App) DecodeWebhook(r *http.Request, maxBodySize int64, v interface{}) error {
+	_, err := app.verifyWebhook(r, defaultWebhookMaxBodySize(maxBodySize), v)
+	return err
+}
+
+func defaultWebhookMaxBodySize(maxBodySize int64) int64 {
+	if maxBodySize == 0 {
+		return DefaultWebhookMaxBodySize
+	}
+	return maxBodySize
+}
+
+// verifyWebhook reads the body of a webhook request through the HMAC,
+// decoding it into v if it isn't nil, and returns it. The body is not limited
+// if maxBodySize is negative. The request body is replaced by the one read.
+func (app App) verifyWebhook(r *http.Request, maxBodySize int64, v interface{}) ([]byte, error) {
+	header := r.Header.Get(shopifyChecksumHeader)
+	if header == "" {
+		return nil, ErrWebhookMissingHMAC
+	}
+	expectedMAC, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return nil, ErrWebhookHMACEncoding
+	}
+	if r.Body == nil {
+		r.Body = ioutil.NopCloser(bytes.NewReader(nil))
+	}
+
+	var body io.Reader = r.Body
+	if maxBodySize >= 0 {
+		// Read one byte more to tell a body of the maximum size from a
+		// larger one
+		body = io.LimitReader(r.Body, maxBodySize+1)
+	}
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	buf := new(bytes.Buffer)
+	body = io.TeeReader(body, io.MultiWriter(mac, buf))
+
+	var decodeErr error
+	if v != nil {
+		decodeErr = json.NewDecoder(body).Decode(v)
+	}
+
+	// Read the rest of the body so that the HMAC covers all of it
+	_, err = io.Copy(ioutil.Discard, body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return nil, WebhookReadError{err}
+	}
+
+	if maxBodySize >= 0 && int64(buf.Len()) > maxBodySize {
+		return nil, ErrWebhookBodyTooLarge
+	}
+	if !hmac.Equal(mac.Sum(nil), expectedMAC) {
+		return nil, ErrWebhookHMACMismatch
+	}
+	if decodeErr != nil {
+		return nil, ResponseDecodingError{Body: buf.Bytes(), Message: decodeErr.Error()}
+	}
+
+	return buf.Bytes(), nil
+}
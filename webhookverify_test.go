@@ -0,0 +1,97 @@
+package goshopify
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	app := App{ApiSecret: "hush"}
+
+	missing := webhookRequest("hush", "orders/create", `{"id": 1}`)
+	missing.Header.Del("X-Shopify-Hmac-Sha256")
+
+	badEncoding := webhookRequest("hush", "orders/create", `{"id": 1}`)
+	badEncoding.Header.Set("X-Shopify-Hmac-Sha256", "not base64!")
+
+	cases := []struct {
+		name     string
+		req      *http.Request
+		max      int64
+		expected error
+	}{
+		{"valid", webhookRequest("hush", "orders/create", `{"id": 1}`), 0, nil},
+		{"exactly max size", webhookRequest("hush", "orders/create", `{"id": 1}`), 9, nil},
+		{"missing hmac", missing, 0, ErrWebhookMissingHMAC},
+		{"bad encoding", badEncoding, 0, ErrWebhookHMACEncoding},
+		{"mismatch", webhookRequest("wrong", "orders/create", `{"id": 1}`), 0, ErrWebhookHMACMismatch},
+		{"too large", webhookRequest("hush", "orders/create", `{"id": 1}`), 8, ErrWebhookBodyTooLarge},
+	}
+
+	for _, c := range cases {
+		err := app.VerifyWebhook(c.req, c.max)
+		if err != c.expected {
+			t.Errorf("App.VerifyWebhook() for %s returned %v, expected %v", c.name, err, c.expected)
+		}
+	}
+}
+
+func TestVerifyWebhookReadError(t *testing.T) {
+	req := webhookRequest("hush", "orders/create", `{"id": 1}`)
+	req.Body = errReader{}
+
+	err := (App{ApiSecret: "hush"}).VerifyWebhook(req, 0)
+	if readErr, ok := err.(WebhookReadError); !ok || readErr.Err.Error() != "test-error" {
+		t.Errorf("App.VerifyWebhook() returned %#v, expected a WebhookReadError", err)
+	}
+}
+
+func TestVerifyWebhookBodyReadable(t *testing.T) {
+	req := webhookRequest("hush", "orders/create", `{"id": 1}`)
+	if err := (App{ApiSecret: "hush"}).VerifyWebhook(req, 0); err != nil {
+		t.Fatalf("App.VerifyWebhook() returned error: %v", err)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil || string(body) != `{"id": 1}` {
+		t.Errorf("Request body after App.VerifyWebhook() = %q, %v, expected the original body", body, err)
+	}
+}
+
+func TestDecodeWebhook(t *testing.T) {
+	app := App{ApiSecret: "hush"}
+
+	order := Order{}
+	err := app.DecodeWebhook(webhookRequest("hush", "orders/create", `{"id": 1, "name": "#1001"}`), 0, &order)
+	if err != nil {
+		t.Fatalf("App.DecodeWebhook() returned error: %v", err)
+	}
+	if order.ID != 1 || order.Name != "#1001" {
+		t.Errorf("App.DecodeWebhook() decoded %+v, expected order 1", order)
+	}
+
+	// The HMAC is checked before the body
+	err = app.DecodeWebhook(webhookRequest("wrong", "orders/create", `{"id": "a"}`), 0, &order)
+	if err != ErrWebhookHMACMismatch {
+		t.Errorf("App.DecodeWebhook() returned %v, expected %v", err, ErrWebhookHMACMismatch)
+	}
+
+	err = app.DecodeWebhook(webhookRequest("hush", "orders/create", `{"id": "a"}`), 0, &order)
+	if _, ok := err.(ResponseDecodingError); !ok {
+		t.Errorf("App.DecodeWebhook() returned %#v, expected a ResponseDecodingError", err)
+	}
+}
+
+func TestWebhookHandlerMaxBodySize(t *testing.T) {
+	handler := NewWebhookHandler(App{ApiSecret: "hush"})
+	handler.MaxBodySize = 8
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, webhookRequest("hush", "orders/create", strings.Repeat("a", 9)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("WebhookHandler responded %d, expected %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}